@@ -0,0 +1,371 @@
+// Package cache is an opt-in HTTP response cache for Jaqpot entities that rarely change:
+// models, features and DOAs. It is an http.RoundTripper keeping responses in an in-memory
+// LRU with a TTL, revalidating expired responses with If-None-Match when the server sent
+// an ETag, and sending concurrent identical requests only once.
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxEntries is the default number of responses kept.
+	DefaultMaxEntries = 1024
+
+	// DefaultTTL is the default time a response is served without asking the server.
+	DefaultTTL = 5 * time.Minute
+)
+
+// Options configures a Cache.
+type Options struct {
+	// MaxEntries is the number of responses kept; the least recently used go first (defaults to DefaultMaxEntries).
+	MaxEntries int
+	// TTL is the time a response is served without asking the server (defaults to DefaultTTL).
+	TTL time.Duration
+	// Cacheable tells which GET requests are cached (defaults to Cacheable).
+	Cacheable func(req *http.Request) bool
+}
+
+// Stats counts how requests were answered.
+type Stats struct {
+	// Hits were answered from the cache, Revalidated after the server answered 304 Not Modified.
+	Hits        int
+	Revalidated int
+	// Misses were sent to the server.
+	Misses int
+	// Shared waited for an identical request in flight.
+	Shared int
+}
+
+// entityPaths are the service paths of the entities cached by default.
+var entityPaths = []string{"/jaqpot/services/model/", "/jaqpot/services/feature/"}
+
+// doaPath is the service path of DOAs, looked up by the hasSources query.
+const doaPath = "/jaqpot/services/doa/"
+
+// Cacheable is the default rule of cached requests: GET of a model or feature by ID, and of a
+// model's DOA. Lists and searches are not cached.
+func Cacheable(req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+	path := req.URL.Path
+	for _, prefix := range entityPaths {
+		if i := strings.Index(path, prefix); i >= 0 {
+			id := path[i+len(prefix):]
+			return id != "" && !strings.Contains(id, "/") && req.URL.RawQuery == ""
+		}
+	}
+	if strings.HasSuffix(path, doaPath) {
+		query := req.URL.Query()
+		return len(query) == 1 && query.Get("hasSources") != ""
+	}
+	return false
+}
+
+// entry is a cached response.
+type entry struct {
+	key     string
+	ids     []string
+	status  int
+	header  http.Header
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+// call is a request in flight that identical requests wait for.
+type call struct {
+	done  chan struct{}
+	entry *entry
+	err   error
+}
+
+// Cache is an http.RoundTripper caching the responses of another one.
+type Cache struct {
+	next http.RoundTripper
+	opts Options
+
+	mu       sync.Mutex
+	lru      *list.List
+	entries  map[string]*list.Element
+	inFlight map[string]*call
+	stats    Stats
+	// generation changes on every invalidation, so responses fetched before it are not stored.
+	generation uint64
+}
+
+// New creates a Cache in front of next (http.DefaultTransport if nil).
+func New(next http.RoundTripper, opts Options) *Cache {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = DefaultMaxEntries
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultTTL
+	}
+	if opts.Cacheable == nil {
+		opts.Cacheable = Cacheable
+	}
+	return &Cache{
+		next:     next,
+		opts:     opts,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+		inFlight: make(map[string]*call),
+	}
+}
+
+// RoundTrip answers cacheable requests from the cache when it can and sends the others on.
+// Successful PUT, PATCH and DELETE requests invalidate the cached responses of their path. POSTs do
+// not: a prediction is posted to the path of its model, and does not change the model. The exception
+// is a POST of a new DOA, which invalidates the cached DOA lookups.
+func (c *Cache) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !c.opts.Cacheable(req) {
+		resp, err := c.next.RoundTrip(req)
+		if err == nil && changesEntity(req) && resp.StatusCode < 400 {
+			c.invalidatePath(req.URL.Path)
+		}
+		return resp, err
+	}
+
+	// Responses depend on who asks: a user may not see another's models.
+	key := req.URL.String() + "\x00" + req.Header.Get("Authorization")
+
+	c.mu.Lock()
+	cached := c.lookup(key)
+	if cached != nil && time.Now().Before(cached.expires) {
+		c.stats.Hits++
+		c.mu.Unlock()
+		return cached.response(req), nil
+	}
+	if inFlight, ok := c.inFlight[key]; ok {
+		c.stats.Shared++
+		c.mu.Unlock()
+		select {
+		case <-inFlight.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if inFlight.err != nil {
+			return nil, inFlight.err
+		}
+		return inFlight.entry.response(req), nil
+	}
+	leader := &call{done: make(chan struct{})}
+	c.inFlight[key] = leader
+	generation := c.generation
+	c.mu.Unlock()
+
+	leader.entry, leader.err = c.fetch(req, key, cached, generation)
+
+	c.mu.Lock()
+	delete(c.inFlight, key)
+	c.mu.Unlock()
+	close(leader.done)
+
+	if leader.err != nil {
+		return nil, leader.err
+	}
+	return leader.entry.response(req), nil
+}
+
+// fetch sends a request, revalidating the expired entry if it has an ETag, and caches a successful response.
+func (c *Cache) fetch(req *http.Request, key string, expired *entry, generation uint64) (*entry, error) {
+	out := req
+	if expired != nil && expired.etag != "" {
+		out = req.Clone(req.Context())
+		out.Header.Set("If-None-Match", expired.etag)
+	}
+
+	resp, err := c.next.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && expired != nil && expired.etag != "" {
+		c.mu.Lock()
+		c.stats.Revalidated++
+		renewed := *expired
+		renewed.expires = time.Now().Add(c.opts.TTL)
+		if c.generation == generation {
+			c.store(&renewed)
+		}
+		c.mu.Unlock()
+		return &renewed, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	fetched := &entry{
+		key:     key,
+		ids:     entityIDs(req.URL),
+		status:  resp.StatusCode,
+		header:  resp.Header.Clone(),
+		body:    body,
+		etag:    resp.Header.Get("ETag"),
+		expires: time.Now().Add(c.opts.TTL),
+	}
+
+	c.mu.Lock()
+	c.stats.Misses++
+	// An empty list, e.g. a DOA lookup of a model without one yet, is not kept.
+	if c.generation == generation && resp.StatusCode == http.StatusOK && !strings.Contains(resp.Header.Get("Cache-Control"), "no-store") &&
+		!bytes.Equal(bytes.TrimSpace(body), []byte("[]")) {
+		c.store(fetched)
+	} else {
+		c.remove(key)
+	}
+	c.mu.Unlock()
+	return fetched, nil
+}
+
+// lookup returns the entry of key, marking it as recently used. c.mu must be held.
+func (c *Cache) lookup(key string) *entry {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(element)
+	return element.Value.(*entry)
+}
+
+// store adds or replaces an entry, evicting the least recently used ones over MaxEntries. c.mu must be held.
+func (c *Cache) store(e *entry) {
+	if element, ok := c.entries[e.key]; ok {
+		element.Value = e
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[e.key] = c.lru.PushFront(e)
+	for c.lru.Len() > c.opts.MaxEntries {
+		c.remove(c.lru.Back().Value.(*entry).key)
+	}
+}
+
+// remove drops the entry of key, if any. c.mu must be held.
+func (c *Cache) remove(key string) {
+	if element, ok := c.entries[key]; ok {
+		c.lru.Remove(element)
+		delete(c.entries, key)
+	}
+}
+
+// Invalidate drops the cached responses of entities by ID, e.g. a model ID drops the model and its DOA.
+// It returns the number of responses dropped.
+func (c *Cache) Invalidate(ids ...string) (dropped int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for element := c.lru.Front(); element != nil; {
+		next := element.Next()
+		e := element.Value.(*entry)
+		if matchesAny(e.ids, ids) {
+			c.remove(e.key)
+			dropped++
+		}
+		element = next
+	}
+	return dropped
+}
+
+// invalidatePath drops the cached responses of a URL path, whatever their query.
+func (c *Cache) invalidatePath(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for element := c.lru.Front(); element != nil; {
+		next := element.Next()
+		e := element.Value.(*entry)
+		if u, err := url.Parse(strings.SplitN(e.key, "\x00", 2)[0]); err == nil && u.Path == path {
+			c.remove(e.key)
+		}
+		element = next
+	}
+}
+
+// Purge drops every cached response.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.lru.Init()
+	c.entries = make(map[string]*list.Element)
+}
+
+// Len returns the number of cached responses.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Stats returns how requests were answered so far.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// response builds a response to req from a cached entry; every caller gets its own body.
+func (e *entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.status, http.StatusText(e.status)),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// changesEntity tells whether a request changes the entity at its path: a PUT, PATCH or DELETE,
+// or the POST of a DOA.
+func changesEntity(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return strings.HasSuffix(req.URL.Path, doaPath)
+	}
+	return false
+}
+
+// entityIDs returns the IDs a request is about: the last segment of its path and the hasSources it looks up.
+func entityIDs(u *url.URL) (ids []string) {
+	if path := strings.TrimRight(u.Path, "/"); path != "" {
+		ids = append(ids, path[strings.LastIndex(path, "/")+1:])
+	}
+	for _, source := range u.Query()["hasSources"] {
+		ids = append(ids, source[strings.LastIndex(source, "/")+1:])
+	}
+	return ids
+}
+
+// matchesAny tells whether any of ids is in wanted.
+func matchesAny(ids []string, wanted []string) bool {
+	for _, id := range ids {
+		for _, w := range wanted {
+			if id == w {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,346 @@
+package cache
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const base = "https://api.jaqpot.org/jaqpot/services/"
+
+// server is a fake Jaqpot API counting the requests it answers.
+type server struct {
+	mu       sync.Mutex
+	requests map[string]int
+	etag     string
+	// release, if set, blocks every answer until it is closed.
+	release chan struct{}
+	status  int
+	header  http.Header
+	// body, if set, answers every request instead of the requested entity.
+	body string
+}
+
+func newServer() *server {
+	return &server{requests: make(map[string]int), status: http.StatusOK}
+}
+
+func (s *server) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	s.requests[req.Method+" "+req.URL.RequestURI()]++
+	release, status, etag, body := s.release, s.status, s.etag, s.body
+	header := http.Header{"Content-Type": {"application/json"}}
+	for key, values := range s.header {
+		header[key] = values
+	}
+	s.mu.Unlock()
+
+	if release != nil {
+		<-release
+	}
+	if etag != "" {
+		header.Set("ETag", etag)
+		if req.Header.Get("If-None-Match") == etag {
+			return &http.Response{StatusCode: http.StatusNotModified, Header: header, Body: ioutil.NopCloser(strings.NewReader("")), Request: req}, nil
+		}
+	}
+	if body == "" {
+		body = `{"_id":"` + req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:] + `"}`
+	}
+	return &http.Response{StatusCode: status, Header: header, Body: ioutil.NopCloser(strings.NewReader(body)), Request: req}, nil
+}
+
+// count returns the number of requests answered for a method and path (with query).
+func (s *server) count(method string, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[method+" /jaqpot/services/"+path]
+}
+
+// do sends a request through the cache and returns the response body.
+func do(t *testing.T, c *Cache, method string, path string, token string) string {
+	t.Helper()
+	var body *strings.Reader
+	if method == http.MethodPost {
+		body = strings.NewReader(url.Values{"dataset_uri": {base + "dataset/d1"}}.Encode())
+	} else {
+		body = strings.NewReader("")
+	}
+	req, err := http.NewRequest(method, base+path, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := c.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestCacheable(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{"GET", "model/m1", true},
+		{"GET", "feature/f1", true},
+		{"GET", "doa/?hasSources=model/m1", true},
+		{"GET", "model/", false},
+		{"GET", "model/?min=0&max=10", false},
+		{"GET", "model/m1/extra", false},
+		{"GET", "model/m1?fields=meta", false},
+		{"GET", "doa/?min=0&max=10", false},
+		{"GET", "dataset/d1", false},
+		{"GET", "task/t1", false},
+		{"POST", "model/m1", false},
+		{"PUT", "feature/f1", false},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest(test.method, base+test.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Cacheable(req); got != test.want {
+			t.Errorf("Cacheable(%s %s) = %v, want %v", test.method, test.path, got, test.want)
+		}
+	}
+}
+
+func TestHitsAndMisses(t *testing.T) {
+	s := newServer()
+	c := New(s, Options{})
+
+	for i := 0; i < 3; i++ {
+		if body := do(t, c, "GET", "model/m1", "alice"); body != `{"_id":"m1"}` {
+			t.Fatalf("body = %s", body)
+		}
+	}
+	do(t, c, "GET", "model/m1", "bob")
+	do(t, c, "GET", "model/?min=0&max=10", "alice")
+	do(t, c, "GET", "model/?min=0&max=10", "alice")
+
+	if got := s.count("GET", "model/m1"); got != 2 {
+		t.Errorf("model fetched %d times, want once per token", got)
+	}
+	if got := s.count("GET", "model/?min=0&max=10"); got != 2 {
+		t.Errorf("list fetched %d times, want every time", got)
+	}
+	if stats := c.Stats(); stats.Hits != 2 || stats.Misses != 2 {
+		t.Errorf("stats = %+v, want 2 hits and 2 misses", stats)
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len = %d, want 2", c.Len())
+	}
+}
+
+func TestPredictionKeepsModel(t *testing.T) {
+	s := newServer()
+	c := New(s, Options{})
+
+	// model.Predict posts the dataset to the model's own path.
+	for i := 0; i < 3; i++ {
+		do(t, c, "GET", "model/m1", "alice")
+		do(t, c, "POST", "model/m1", "alice")
+	}
+	if got := s.count("GET", "model/m1"); got != 1 {
+		t.Errorf("model fetched %d times across 3 predictions, want 1", got)
+	}
+	if got := s.count("POST", "model/m1"); got != 3 {
+		t.Errorf("predictions sent %d times, want 3", got)
+	}
+}
+
+func TestChangesInvalidate(t *testing.T) {
+	for _, method := range []string{"PUT", "PATCH", "DELETE"} {
+		s := newServer()
+		c := New(s, Options{})
+
+		do(t, c, "GET", "feature/f1", "alice")
+		do(t, c, "GET", "feature/f2", "alice")
+		do(t, c, method, "feature/f1", "alice")
+		do(t, c, "GET", "feature/f1", "alice")
+		do(t, c, "GET", "feature/f2", "alice")
+
+		if got := s.count("GET", "feature/f1"); got != 2 {
+			t.Errorf("%s: changed feature fetched %d times, want 2", method, got)
+		}
+		if got := s.count("GET", "feature/f2"); got != 1 {
+			t.Errorf("%s: other feature fetched %d times, want 1", method, got)
+		}
+	}
+}
+
+func TestNewDOAInvalidatesLookups(t *testing.T) {
+	s := newServer()
+	c := New(s, Options{})
+
+	do(t, c, "GET", "doa/?hasSources=model/m1", "alice")
+	do(t, c, "GET", "doa/?hasSources=model/m1", "alice")
+	do(t, c, "POST", "doa/", "alice")
+	do(t, c, "GET", "doa/?hasSources=model/m1", "alice")
+
+	if got := s.count("GET", "doa/?hasSources=model/m1"); got != 2 {
+		t.Errorf("DOA looked up %d times, want again after a DOA was created", got)
+	}
+}
+
+func TestEmptyListNotStored(t *testing.T) {
+	s := newServer()
+	s.body = " [] "
+	c := New(s, Options{})
+
+	do(t, c, "GET", "doa/?hasSources=model/m1", "alice")
+	do(t, c, "GET", "doa/?hasSources=model/m1", "alice")
+
+	if got := s.count("GET", "doa/?hasSources=model/m1"); got != 2 {
+		t.Errorf("empty DOA lookup sent %d times, want every time", got)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len = %d, want 0", c.Len())
+	}
+}
+
+func TestFailedChangeKeepsEntry(t *testing.T) {
+	s := newServer()
+	c := New(s, Options{})
+
+	do(t, c, "GET", "model/m1", "alice")
+	s.status = http.StatusForbidden
+	do(t, c, "DELETE", "model/m1", "alice")
+	s.status = http.StatusOK
+	do(t, c, "GET", "model/m1", "alice")
+
+	if got := s.count("GET", "model/m1"); got != 1 {
+		t.Errorf("model fetched %d times after a failed delete, want 1", got)
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	s := newServer()
+	c := New(s, Options{})
+
+	do(t, c, "GET", "model/m1", "alice")
+	do(t, c, "GET", "doa/?hasSources=model/m1", "alice")
+	do(t, c, "GET", "model/m2", "alice")
+
+	if dropped := c.Invalidate("m1"); dropped != 2 {
+		t.Errorf("Invalidate dropped %d responses, want the model and its DOA", dropped)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len = %d, want 1", c.Len())
+	}
+
+	c.Purge()
+	if c.Len() != 0 {
+		t.Errorf("Len = %d after Purge", c.Len())
+	}
+}
+
+func TestEviction(t *testing.T) {
+	s := newServer()
+	c := New(s, Options{MaxEntries: 2})
+
+	do(t, c, "GET", "model/m1", "alice")
+	do(t, c, "GET", "model/m2", "alice")
+	do(t, c, "GET", "model/m1", "alice") // m1 is now the most recently used
+	do(t, c, "GET", "model/m3", "alice") // evicts m2
+	do(t, c, "GET", "model/m1", "alice")
+	do(t, c, "GET", "model/m2", "alice")
+
+	if got := s.count("GET", "model/m1"); got != 1 {
+		t.Errorf("m1 fetched %d times, want 1", got)
+	}
+	if got := s.count("GET", "model/m2"); got != 2 {
+		t.Errorf("m2 fetched %d times, want 2", got)
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len = %d, want MaxEntries", c.Len())
+	}
+}
+
+func TestRevalidation(t *testing.T) {
+	s := newServer()
+	s.etag = `"v1"`
+	c := New(s, Options{TTL: time.Millisecond})
+
+	do(t, c, "GET", "model/m1", "alice")
+	time.Sleep(5 * time.Millisecond)
+	if body := do(t, c, "GET", "model/m1", "alice"); body != `{"_id":"m1"}` {
+		t.Errorf("revalidated body = %q, want the cached one", body)
+	}
+
+	if got := s.count("GET", "model/m1"); got != 2 {
+		t.Errorf("model fetched %d times, want 2", got)
+	}
+	if stats := c.Stats(); stats.Revalidated != 1 || stats.Misses != 1 {
+		t.Errorf("stats = %+v, want 1 revalidation", stats)
+	}
+}
+
+func TestNotStored(t *testing.T) {
+	s := newServer()
+	c := New(s, Options{})
+
+	s.status = http.StatusNotFound
+	do(t, c, "GET", "model/m1", "alice")
+	s.status = http.StatusOK
+	s.header = http.Header{"Cache-Control": {"no-store"}}
+	do(t, c, "GET", "model/m1", "alice")
+	do(t, c, "GET", "model/m1", "alice")
+
+	if got := s.count("GET", "model/m1"); got != 3 {
+		t.Errorf("model fetched %d times, want every time", got)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len = %d, want 0", c.Len())
+	}
+}
+
+func TestSharedRequests(t *testing.T) {
+	s := newServer()
+	s.release = make(chan struct{})
+	c := New(s, Options{})
+
+	const callers = 8
+	var wg sync.WaitGroup
+	bodies := make([]string, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			bodies[i] = do(t, c, "GET", "model/m1", "alice")
+		}(i)
+	}
+
+	// Let every caller reach the cache before the server answers.
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if c.Stats().Shared == callers-1 {
+			break
+		}
+	}
+	close(s.release)
+	wg.Wait()
+
+	if got := s.count("GET", "model/m1"); got != 1 {
+		t.Errorf("model fetched %d times, want 1", got)
+	}
+	for i, body := range bodies {
+		if body != `{"_id":"m1"}` {
+			t.Errorf("caller %d got %q", i, body)
+		}
+	}
+}
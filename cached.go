@@ -0,0 +1,43 @@
+package gojaqpot
+
+import (
+	"net/http"
+
+	"github.com/euclia/gojaqpot/cache"
+)
+
+// EnableCache is a method to cache the responses of model, feature and DOA lookups in memory,
+// e.g. the model fetched by every prediction. It wraps the transport of the client's HTTP client
+// (a copy of it, so a shared client is left as it is) and returns the cache; a cache already
+// enabled is returned as it is.
+func (client *Client) EnableCache(opts cache.Options) (responseCache *cache.Cache) {
+	if responseCache = client.responseCache(); responseCache != nil {
+		return responseCache
+	}
+
+	httpClient := &http.Client{}
+	if client.C.HTTPClient != nil {
+		*httpClient = *client.C.HTTPClient
+	}
+	responseCache = cache.New(httpClient.Transport, opts)
+	httpClient.Transport = responseCache
+	client.C.HTTPClient = httpClient
+	return responseCache
+}
+
+// InvalidateCache is a method to drop the cached responses of entities by ID (e.g. a model ID drops
+// the model and its DOA). It does nothing when the cache is not enabled.
+func (client *Client) InvalidateCache(ids ...string) {
+	if responseCache := client.responseCache(); responseCache != nil {
+		responseCache.Invalidate(ids...)
+	}
+}
+
+// responseCache returns the client's cache, or nil if it is not enabled.
+func (client *Client) responseCache() *cache.Cache {
+	if client.C.HTTPClient == nil {
+		return nil
+	}
+	responseCache, _ := client.C.HTTPClient.Transport.(*cache.Cache)
+	return responseCache
+}
@@ -1,18 +1,22 @@
 package gojaqpot
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/euclia/gojaqpot/cache"
 	"github.com/euclia/gojaqpot/dataset"
 	"github.com/euclia/gojaqpot/doa"
 	"github.com/euclia/gojaqpot/feature"
 	"github.com/euclia/gojaqpot/model"
 	"github.com/euclia/gojaqpot/models"
+	"github.com/euclia/gojaqpot/schema"
 	"github.com/euclia/gojaqpot/task"
+	"github.com/euclia/gojaqpot/units"
 )
 
 const (
@@ -46,18 +50,61 @@ type IJaqpotClient interface {
 	// Get a Jaqpot Feature by its id.
 	GetFeature(featureID string, AuthToken string) (feat models.Feature, err error)
 
+	// GetFeatures is a method to get several features by ID (or URI) concurrently.
+	GetFeatures(featureIDs []string, AuthToken string) (feats []models.Feature, err error)
+
+	// ListFeatures is a method to get a list of features.
+	ListFeatures(min int, max int, AuthToken string) (feats models.Features, err error)
+
+	// SearchFeatures is a method to get a list of features by title and/or ontological class.
+	SearchFeatures(title string, ontologicalClass string, min int, max int, AuthToken string) (feats models.Features, err error)
+
+	// CreateFeature is a method to create a feature.
+	CreateFeature(feat models.Feature, AuthToken string) (retFeat models.Feature, err error)
+
+	// UpdateFeature is a method to update a feature.
+	UpdateFeature(featureID string, feat models.Feature, AuthToken string) (retFeat models.Feature, err error)
+
 	// Get a Jaqpot Dataset by its id.
 	GetDataset(datasetID string, AuthToken string) (data models.Dataset, err error)
 
+	// GetMyDatasets is a method to get a list of user's datasets.
+	GetMyDatasets(min int, max int, AuthToken string) (myDatasets models.Datasets, err error)
+
 	// Get a model's DOA by the model's id.
 	GetDOA(modelID string, AuthToken string) (modelDoa models.Doa, err error)
 
+	// GetDOAs is a method to get a list of DOAs.
+	GetDOAs(min int, max int, AuthToken string) (doas models.Doas, err error)
+
+	// CreateDOA is a method to create a model's DOA from its training dataset.
+	CreateDOA(modelID string, AuthToken string) (modelDoa models.Doa, err error)
+
+	// RecomputeDOA is a method to recompute a model's DOA after retraining.
+	RecomputeDOA(modelID string, AuthToken string) (modelDoa models.Doa, err error)
+
 	// Get a Task by its id.
 	GetTask(taskID string, AuthToken string) (returnTask models.Task, err error)
 
+	// GetMyTasks is a method to get a list of user's tasks, optionally filtered by status and type.
+	// With a type, Total is the number of tasks returned rather than the server's count; see task.GetMyTasks.
+	GetMyTasks(status models.TaskStatus, taskType string, min int, max int, AuthToken string) (myTasks models.Tasks, err error)
+
+	// CancelTask is a method to cancel a running task.
+	CancelTask(taskID string, AuthToken string) (returnTask models.Task, err error)
+
+	// DeleteTask is a method to delete a finished task.
+	DeleteTask(taskID string, AuthToken string) (returnTask models.Task, err error)
+
+	// NewTaskWatcher is a method to create a watcher polling many tasks on one shared schedule.
+	NewTaskWatcher(AuthToken string, opts task.WatcherOptions) (watcher *task.Watcher)
+
 	// GetModel is a method to get a model by ID.
 	GetModel(modelID string, AuthToken string) (retModel models.Model, err error)
 
+	// ModelSchema is a method to describe a model's inputs and outputs by resolving its features.
+	ModelSchema(modelID string, AuthToken string) (modelSchema models.ModelSchema, err error)
+
 	// GetMyModels is a method to get a list of user's models.
 	GetMyModels(min int, max int, AuthToken string) (myModels models.Models, err error)
 
@@ -67,8 +114,35 @@ type IJaqpotClient interface {
 	// GetOrgsModelsByTag is a method to get a list of an organization's models with a particular tag.
 	GetOrgsModelsByTag(organizationID string, tag string, min int, max int, AuthToken string) (tagModels models.Models, err error)
 
+	// ValidateInput is a method to check prediction inputs against a model's features without uploading them.
+	ValidateInput(modelID string, values []map[string]interface{}, AuthToken string) (err error)
+
 	// Predict is a method to make a prediction on a Jaqpot Dataset (returns the task ID).
 	Predict(modelID string, values []map[string]interface{}, AuthToken string) (prediction models.Prediction, err error)
+
+	// PredictWithProgress is a method to make a prediction, reporting every poll of its task to onUpdate.
+	PredictWithProgress(modelID string, values []map[string]interface{}, onUpdate func(models.Task), AuthToken string) (prediction models.Prediction, err error)
+
+	// PredictContext is a method to make a prediction that stops polling its task when ctx is done.
+	PredictContext(ctx context.Context, modelID string, values []map[string]interface{}, onUpdate func(models.Task), AuthToken string) (prediction models.Prediction, err error)
+
+	// PredictInUnits is a method to make a prediction on values given in other units than the model expects.
+	PredictInUnits(modelID string, values []map[string]interface{}, valueUnits map[string]string, normalizer *units.Normalizer, AuthToken string) (prediction models.Prediction, err error)
+
+	// PredictStructs is a method to make a prediction from structs tagged with Jaqpot feature names.
+	PredictStructs(modelID string, rows interface{}, out interface{}, AuthToken string) (prediction models.Prediction, err error)
+
+	// PredictDataset is a method to make a prediction on an existing Jaqpot Dataset, without uploading a new one.
+	PredictDataset(modelID string, datasetID string, AuthToken string) (prediction models.Prediction, err error)
+
+	// PredictMany is a method to make the same prediction with several models concurrently.
+	PredictMany(modelIDs []string, values []map[string]interface{}, AuthToken string, opts PredictManyOptions) (multi models.MultiPrediction, err error)
+
+	// EnableCache is a method to cache the responses of model, feature and DOA lookups.
+	EnableCache(opts cache.Options) (responseCache *cache.Cache)
+
+	// InvalidateCache is a method to drop the cached responses of entities by ID.
+	InvalidateCache(ids ...string)
 }
 
 // GetFeature is a method to get a feature by ID.
@@ -76,26 +150,98 @@ func (client *Client) GetFeature(featureID string, AuthToken string) (feat model
 	return feature.GetFeature(featureID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
 }
 
+// GetFeatures is a method to get several features by ID (or URI) concurrently.
+func (client *Client) GetFeatures(featureIDs []string, AuthToken string) (feats []models.Feature, err error) {
+	return feature.GetFeatures(featureIDs, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
+// ListFeatures is a method to get a list of features.
+func (client *Client) ListFeatures(min int, max int, AuthToken string) (feats models.Features, err error) {
+	return feature.ListFeatures(min, max, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
+// SearchFeatures is a method to get a list of features by title and/or ontological class.
+func (client *Client) SearchFeatures(title string, ontologicalClass string, min int, max int, AuthToken string) (feats models.Features, err error) {
+	return feature.SearchFeatures(title, ontologicalClass, min, max, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
+// CreateFeature is a method to create a feature.
+func (client *Client) CreateFeature(feat models.Feature, AuthToken string) (retFeat models.Feature, err error) {
+	return feature.CreateFeature(feat, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
+// UpdateFeature is a method to update a feature.
+func (client *Client) UpdateFeature(featureID string, feat models.Feature, AuthToken string) (retFeat models.Feature, err error) {
+	return feature.UpdateFeature(featureID, feat, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
 // GetDataset is a method to get a Dataset by ID.
 func (client *Client) GetDataset(datasetID string, AuthToken string) (data models.Dataset, err error) {
 	return dataset.GetDataset(datasetID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
 }
 
+// GetMyDatasets is a method to get a list of user's datasets.
+func (client *Client) GetMyDatasets(min int, max int, AuthToken string) (myDatasets models.Datasets, err error) {
+	return dataset.GetMyDatasets(min, max, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
 // GetDOA is a method to get a model's DOA, by its ID.
 func (client *Client) GetDOA(modelID string, AuthToken string) (modelDoa models.Doa, err error) {
 	return doa.GetDOA(modelID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
 }
 
+// GetDOAs is a method to get a list of DOAs.
+func (client *Client) GetDOAs(min int, max int, AuthToken string) (doas models.Doas, err error) {
+	return doa.GetDOAs(min, max, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
+// CreateDOA is a method to create a model's DOA from its training dataset.
+func (client *Client) CreateDOA(modelID string, AuthToken string) (modelDoa models.Doa, err error) {
+	return doa.CreateDOA(modelID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
+// RecomputeDOA is a method to recompute a model's DOA after retraining.
+func (client *Client) RecomputeDOA(modelID string, AuthToken string) (modelDoa models.Doa, err error) {
+	return doa.RecomputeDOA(modelID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
 // GetTask is a method to get a Task its ID.
 func (client *Client) GetTask(taskID string, AuthToken string) (returnTask models.Task, err error) {
 	return task.GetTask(taskID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
 }
 
+// GetMyTasks is a method to get a list of user's tasks, optionally filtered by status and type.
+// With a type, Total is the number of tasks returned rather than the server's count; see task.GetMyTasks.
+func (client *Client) GetMyTasks(status models.TaskStatus, taskType string, min int, max int, AuthToken string) (myTasks models.Tasks, err error) {
+	return task.GetMyTasks(status, taskType, min, max, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
+// CancelTask is a method to cancel a running task.
+func (client *Client) CancelTask(taskID string, AuthToken string) (returnTask models.Task, err error) {
+	return task.CancelTask(taskID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
+// DeleteTask is a method to delete a finished task.
+func (client *Client) DeleteTask(taskID string, AuthToken string) (returnTask models.Task, err error) {
+	return task.DeleteTask(taskID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
+// NewTaskWatcher is a method to create a watcher polling many tasks on one shared schedule.
+// The watcher runs until its Stop method is called.
+func (client *Client) NewTaskWatcher(AuthToken string, opts task.WatcherOptions) (watcher *task.Watcher) {
+	return task.NewWatcher(AuthToken, client.C.BaseURL, client.C.HTTPClient, opts)
+}
+
 // GetModel is a method to get a model by ID.
 func (client *Client) GetModel(modelID string, AuthToken string) (retModel models.Model, err error) {
 	return model.GetModel(modelID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
 }
 
+// ModelSchema is a method to describe a model's inputs and outputs by resolving its features.
+func (client *Client) ModelSchema(modelID string, AuthToken string) (modelSchema models.ModelSchema, err error) {
+	return schema.GetModelSchema(modelID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
 // GetMyModels is a method to get a list of user's models.
 func (client *Client) GetMyModels(min int, max int, AuthToken string) (myModels models.Models, err error) {
 	return model.GetMyModels(min, max, AuthToken, client.C.BaseURL, client.C.HTTPClient)
@@ -111,53 +257,145 @@ func (client *Client) GetOrgsModelsByTag(organizationID string, tag string, min
 	return model.GetOrgsModelsByTag(organizationID, tag, min, max, AuthToken, client.C.BaseURL, client.C.HTTPClient)
 }
 
+// ValidateInput is a method to check prediction inputs against a model's features without uploading them.
+// A *dataset.ValidationError lists every problem found, row by row.
+func (client *Client) ValidateInput(modelID string, values []map[string]interface{}, AuthToken string) (err error) {
+	return dataset.ValidateValues(modelID, values, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+}
+
 // Predict is a method to make a prediction on a Jaqpot Dataset (returns the task ID).
 func (client *Client) Predict(modelID string, values []map[string]interface{}, AuthToken string) (prediction models.Prediction, err error) {
+	return client.PredictWithProgress(modelID, values, nil, AuthToken)
+}
+
+// PredictWithProgress is a method to make a prediction, reporting every poll of its task to onUpdate
+// (e.g. to show its PercentageCompleted). onUpdate may be nil.
+func (client *Client) PredictWithProgress(modelID string, values []map[string]interface{}, onUpdate func(models.Task), AuthToken string) (prediction models.Prediction, err error) {
+	return client.PredictContext(context.Background(), modelID, values, onUpdate, AuthToken)
+}
+
+// PredictContext is a method to make a prediction, reporting every poll of its task to onUpdate (which may be nil).
+// When ctx is done, e.g. because the caller went away, the prediction stops waiting and returns ctx.Err();
+// a task already started keeps running on the server.
+func (client *Client) PredictContext(ctx context.Context, modelID string, values []map[string]interface{}, onUpdate func(models.Task), AuthToken string) (prediction models.Prediction, err error) {
 
 	var retPrediction models.Prediction
-	var predTask models.Task
 
-	jaqDataset := dataset.CreateDataset(modelID, values, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+	jaqDataset, internalError := dataset.CreateDataset(modelID, values, AuthToken, client.C.BaseURL, client.C.HTTPClient)
 
-	datasetID, internalError := dataset.PostDataset(jaqDataset, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+	if internalError != nil {
+		return retPrediction, internalError
+	}
 
-	taskID, internalError := model.Predict(modelID, datasetID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+	if internalError = ctx.Err(); internalError != nil {
+		return retPrediction, internalError
+	}
+
+	datasetID, internalError := dataset.PostDataset(jaqDataset, AuthToken, client.C.BaseURL, client.C.HTTPClient)
 
 	if internalError != nil {
-		fmt.Printf(internalError.Error())
 		return retPrediction, internalError
 	}
 
-	for true {
-		predTask, internalError = task.GetTask(taskID.SlashID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
-		percent := predTask.PercentageCompleted
-		dataID := predTask.Result
+	return client.predictDataset(ctx, modelID, datasetID, onUpdate, nil, AuthToken)
+}
 
-		if percent == 100 {
-			retPrediction.ModelID = modelID
-			retPrediction.DatasetID = strings.Split(dataID, "/")[1]
-			break
-		} else {
-			time.Sleep(1 * time.Second)
-		}
+// PredictInUnits is a method to make a prediction on values given in other units than the model expects.
+// valueUnits maps feature names to the units of their values (e.g. "mg/L"); the values are converted
+// into the units of the model's features before prediction. normalizer sets the unit registry and the
+// molar masses needed to convert between mass and amount of substance (e.g. mg/L to µM); nil uses a zero Normalizer.
+func (client *Client) PredictInUnits(modelID string, values []map[string]interface{}, valueUnits map[string]string, normalizer *units.Normalizer, AuthToken string) (prediction models.Prediction, err error) {
+	modelSchema, err := client.ModelSchema(modelID, AuthToken)
+	if err != nil {
+		return prediction, err
+	}
+
+	if normalizer == nil {
+		normalizer = &units.Normalizer{}
+	}
+	normalized, err := normalizer.NormalizeRows(modelSchema.Inputs, values, valueUnits)
+	if err != nil {
+		return prediction, err
+	}
+
+	return client.Predict(modelID, normalized, AuthToken)
+}
 
+// PredictDataset is a method to make a prediction on an existing Jaqpot Dataset, without uploading a new one.
+func (client *Client) PredictDataset(modelID string, datasetID string, AuthToken string) (prediction models.Prediction, err error) {
+	return client.predictDataset(context.Background(), modelID, datasetID, nil, nil, AuthToken)
+}
+
+// predictDataset makes a prediction on a dataset, reporting every poll of its task to onUpdate,
+// until ctx is done. The task is polled by watcher if one is given (onUpdate is then not called),
+// on its own otherwise.
+func (client *Client) predictDataset(ctx context.Context, modelID string, datasetID string, onUpdate func(models.Task), watcher *task.Watcher, AuthToken string) (prediction models.Prediction, err error) {
+
+	var retPrediction models.Prediction
+	var predTask models.Task
+
+	taskID, internalError := model.Predict(modelID, datasetID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+
+	if internalError != nil {
+		return retPrediction, internalError
+	}
+
+	if watcher != nil {
+		predTask, internalError = watcher.AwaitContext(ctx, taskID.SlashID)
+	} else {
+		predTask, internalError = task.WaitContext(ctx, taskID.SlashID, AuthToken, client.C.BaseURL, client.C.HTTPClient, onUpdate)
 	}
 
 	if internalError != nil {
-		fmt.Printf(internalError.Error())
 		return retPrediction, internalError
 	}
 
+	retPrediction.ModelID = modelID
+	retPrediction.DatasetID = resultID(predTask.Result)
+
 	retPrediction.Data, retPrediction.Predictions, internalError = formatPreds(retPrediction.DatasetID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
 
 	if internalError != nil {
-		fmt.Printf(internalError.Error())
 		return retPrediction, internalError
 	}
 
+	if client.C.AttachDOA {
+		internalError = client.attachDOA(&retPrediction, AuthToken)
+		if internalError != nil {
+			return retPrediction, internalError
+		}
+	}
+
 	return retPrediction, err
 }
 
+// attachDOA sets the applicability-domain assessment of every predicted row.
+// Models without a DOA (the lookup answers 404 Not Found) are left without one.
+func (client *Client) attachDOA(prediction *models.Prediction, AuthToken string) (err error) {
+	currentModel, err := model.GetModel(prediction.ModelID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+	if err != nil {
+		return err
+	}
+
+	modelDoa, err := doa.GetDOA(prediction.ModelID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+	var apiErr *models.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil || len(modelDoa.DoaMatrix) == 0 {
+		return err
+	}
+
+	prediction.Domain, err = doa.AssessModel(currentModel, modelDoa, prediction.Data)
+	return err
+}
+
+// resultID returns the ID of the entity a task result (e.g. "dataset/<id>") points to.
+func resultID(result string) string {
+	currList := strings.Split(strings.TrimRight(result, "/"), "/")
+	return currList[len(currList)-1]
+}
+
 func formatPreds(datasetID string, AuthToken string, BaseURL string, HTTPClient *http.Client) (data []map[string]interface{}, preds []map[string]interface{}, err error) {
 	predDataset, internalError := dataset.GetDataset(datasetID, AuthToken, BaseURL, HTTPClient)
 	var endpoint string
@@ -166,8 +404,7 @@ func formatPreds(datasetID string, AuthToken string, BaseURL string, HTTPClient
 	var retPreds []map[string]interface{}
 
 	if internalError != nil {
-		fmt.Printf(internalError.Error())
-		return retData, retPreds, err
+		return retData, retPreds, internalError
 	}
 
 	for _, item := range predDataset.Features {
@@ -0,0 +1,94 @@
+// Command jaqpot-gateway serves Jaqpot predictions over a simplified REST API:
+//
+//	POST /predict/{modelId}          body: a JSON array of rows, e.g. [{"feature": 1.5}, ...]
+//	POST /predict-stream/{modelId}   same body; streams the task progress as Server-Sent Events
+//
+// Callers' bearer tokens are forwarded to Jaqpot and callers without one are refused. With
+// -service-token they use the token of the selected config profile instead, and with
+// -service-token-only every request does (see package github.com/euclia/gojaqpot/config).
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	gojaqpot "github.com/euclia/gojaqpot"
+	"github.com/euclia/gojaqpot/config"
+	"github.com/euclia/gojaqpot/gateway"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	configPath := flag.String("config", config.DefaultPath(), "config file")
+	profileName := flag.String("profile", "", "config profile (default $"+config.EnvProfile+" or the file's current profile)")
+	useService := flag.Bool("service-token", false, "use the profile's token for requests without a bearer token")
+	serviceOnly := flag.Bool("service-token-only", false, "use the profile's token for every request, ignoring callers' tokens")
+	maxBody := flag.Int64("max-body", gateway.DefaultMaxBodyBytes, "maximum request body size in bytes")
+	maxRows := flag.Int("max-rows", gateway.DefaultMaxRows, "maximum rows per request")
+	flag.Parse()
+
+	file, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("jaqpot-gateway: reading config: %s", err.Error())
+	}
+	profile, err := file.Profile(*profileName)
+	if err != nil {
+		log.Fatalf("jaqpot-gateway: %s", err.Error())
+	}
+	var serviceToken string
+	if *useService || *serviceOnly {
+		serviceToken, err = profile.ResolveToken()
+		if err == config.ErrNoToken {
+			log.Fatalf("jaqpot-gateway: profile %s has no token to use as the service token", profile.Name)
+		}
+		if err != nil {
+			log.Fatalf("jaqpot-gateway: %s", err.Error())
+		}
+	}
+	client, err := gojaqpot.NewClient(profile)
+	if err != nil {
+		log.Fatalf("jaqpot-gateway: %s", err.Error())
+	}
+
+	opts := gateway.Options{
+		ServiceToken:      serviceToken,
+		IgnoreCallerToken: *serviceOnly,
+		MaxBodyBytes:      *maxBody,
+		MaxRows:           *maxRows,
+	}
+	mux := http.NewServeMux()
+	mux.Handle(gateway.PredictPath, gateway.NewHandler(client, opts))
+	mux.Handle(gateway.StreamPath, gateway.NewStreamHandler(client, opts))
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-stop
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("jaqpot-gateway: shutdown: %s", err.Error())
+		}
+	}()
+
+	log.Printf("jaqpot-gateway: serving profile %s (%s) on %s", profile.Name, profile.NormalizedBaseURL(), *addr)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatalf("jaqpot-gateway: %s", err.Error())
+	}
+	// Wait for the requests in flight to finish.
+	<-stopped
+}
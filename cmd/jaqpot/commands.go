@@ -0,0 +1,257 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/euclia/gojaqpot/models"
+	"github.com/euclia/gojaqpot/task"
+)
+
+// subFlags creates the flag set of a subcommand; parse errors are reported as usage errors.
+func subFlags(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	return flags
+}
+
+// oneArg parses a subcommand taking exactly one positional argument.
+func oneArg(args []string) (arg string, err error) {
+	if len(args) != 1 {
+		return "", errUsage
+	}
+	return args[0], nil
+}
+
+// modelsList lists the user's models, or an organization's models optionally by tag.
+func modelsList(a *app, args []string) error {
+	flags := subFlags("models list")
+	min := flags.Int("min", 0, "first model")
+	max := flags.Int("max", 20, "number of models")
+	org := flags.String("org", "", "organization")
+	tag := flags.String("tag", "", "tag (with -org)")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 || (*tag != "" && *org == "") {
+		return errUsage
+	}
+
+	var list models.Models
+	var err error
+	switch {
+	case *tag != "":
+		list, err = a.client.GetOrgsModelsByTag(*org, *tag, *min, *max, a.token)
+	case *org != "":
+		list, err = a.client.GetOrgsModels(*org, *min, *max, a.token)
+	default:
+		list, err = a.client.GetMyModels(*min, *max, a.token)
+	}
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(list.Models))
+	for _, m := range list.Models {
+		rows = append(rows, modelRow(m))
+	}
+	if err := a.out.table(list, modelHeader, rows); err != nil {
+		return err
+	}
+	if !a.out.json {
+		fmt.Fprintf(a.out.w, "\n%d of %d models\n", len(list.Models), list.Total)
+	}
+	return nil
+}
+
+// modelsGet shows a model and its features.
+func modelsGet(a *app, args []string) error {
+	id, err := oneArg(args)
+	if err != nil {
+		return err
+	}
+	m, err := a.client.GetModel(id, a.token)
+	if err != nil {
+		return err
+	}
+	independent, err := m.IndependentFeatureNames()
+	if err != nil {
+		independent = m.IndependentFeatures
+	}
+
+	rows := [][]string{
+		{"ID", modelID(m)},
+		{"TITLE", title(m.Meta)},
+		{"ALGORITHM", orDash(m.Algorithm.ID)},
+		{"DATE", date(m.Meta)},
+		{"CREATORS", join(m.Meta.Creators)},
+		{"TAGS", join(m.Meta.Tags)},
+		{"INDEPENDENT", join(independent)},
+		{"PREDICTED", join(m.PredictedFeatures)},
+		{"DATASET", orDash(m.DatasetURI)},
+		{"DOA", orDash(m.DoaModel)},
+	}
+	return a.out.table(m, []string{"FIELD", "VALUE"}, rows)
+}
+
+// datasetsGet shows a dataset and its features.
+func datasetsGet(a *app, args []string) error {
+	id, err := oneArg(args)
+	if err != nil {
+		return err
+	}
+	d, err := a.client.GetDataset(id, a.token)
+	if err != nil {
+		return err
+	}
+	if a.out.json {
+		return a.out.encode(d)
+	}
+
+	fmt.Fprintf(a.out.w, "%s  %s  %d rows x %d columns  %s\n\n", orDash(d.SlashID), title(d.Meta), d.TotalRows, d.TotalColumns, date(d.Meta))
+	rows := make([][]string, 0, len(d.Features))
+	for _, f := range d.Features {
+		rows = append(rows, []string{orDash(f.Key), orDash(f.Name), orDash(f.Units), orDash(f.Category), orDash(f.URI)})
+	}
+	return a.out.table(d, []string{"KEY", "NAME", "UNITS", "CATEGORY", "URI"}, rows)
+}
+
+// featuresGet shows one or more features.
+func featuresGet(a *app, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	feats, err := a.client.GetFeatures(args, a.token)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(feats))
+	for _, f := range feats {
+		id := f.ID
+		if id == "" {
+			id = f.SlashID
+		}
+		rows = append(rows, []string{orDash(id), title(f.Meta), orDash(f.Units), join(f.AdmissibleValues), orDash(f.PredictorFor)})
+	}
+	return a.out.table(feats, []string{"ID", "TITLE", "UNITS", "ADMISSIBLE", "PREDICTOR FOR"}, rows)
+}
+
+// tasksGet shows a task.
+func tasksGet(a *app, args []string) error {
+	id, err := oneArg(args)
+	if err != nil {
+		return err
+	}
+	t, err := a.client.GetTask(id, a.token)
+	if err != nil {
+		return err
+	}
+	return a.out.table(t, taskHeader, [][]string{taskRow(t)})
+}
+
+// tasksWatch follows tasks until they all finish, printing every change. It fails if any task fails.
+func tasksWatch(a *app, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	// The watcher sends one final event per task, however many times it is named.
+	var taskIDs []string
+	seen := make(map[string]bool, len(args))
+	for _, id := range args {
+		if !seen[id] {
+			seen[id] = true
+			taskIDs = append(taskIDs, id)
+		}
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	watcher := a.client.NewTaskWatcher(a.token, task.WatcherOptions{})
+	defer watcher.Stop()
+	events := watcher.Events()
+	watcher.Watch(taskIDs...)
+
+	if !a.out.json {
+		fmt.Fprintln(a.out.w, watchLine(taskHeader))
+	}
+	var failed []string
+	for pending := len(taskIDs); pending > 0; {
+		select {
+		case <-interrupt:
+			return fmt.Errorf("interrupted with %d task(s) in flight", pending)
+		case event := <-events:
+			if err := a.printEvent(event); err != nil {
+				return err
+			}
+			if event.Done {
+				pending--
+				if event.Err != nil {
+					failed = append(failed, event.TaskID+": "+event.Err.Error())
+				}
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d task(s) failed:\n  %s", len(failed), strings.Join(failed, "\n  "))
+	}
+	return nil
+}
+
+// printEvent writes a task event as a table row or a JSON line.
+func (a *app) printEvent(event task.Event) error {
+	if a.out.json {
+		line := struct {
+			TaskID string      `json:"taskId"`
+			Done   bool        `json:"done"`
+			Error  string      `json:"error,omitempty"`
+			Task   models.Task `json:"task"`
+		}{TaskID: event.TaskID, Done: event.Done, Task: event.Task}
+		if event.Err != nil {
+			line.Error = event.Err.Error()
+		}
+		return a.out.encode(line)
+	}
+
+	row := taskRow(event.Task)
+	row[0] = event.TaskID
+	if event.Err != nil {
+		row[len(row)-1] = event.Err.Error()
+	}
+	_, err := fmt.Fprintln(a.out.w, watchLine(row))
+	return err
+}
+
+// watchLine lays out a task row in fixed-width columns, since watched rows are printed as they come.
+func watchLine(row []string) string {
+	return fmt.Sprintf("%-26s  %-10s  %8s  %-12s  %-26s  %s", row[0], row[1], row[2], row[3], row[4], row[5])
+}
+
+// doaGet shows a model's domain of applicability.
+func doaGet(a *app, args []string) error {
+	id, err := oneArg(args)
+	if err != nil {
+		return err
+	}
+	d, err := a.client.GetDOA(id, a.token)
+	if err != nil {
+		return err
+	}
+
+	size := strconv.Itoa(len(d.DoaMatrix))
+	if len(d.DoaMatrix) > 0 {
+		size += "x" + strconv.Itoa(len(d.DoaMatrix[0]))
+	}
+	rows := [][]string{
+		{"ID", orDash(d.ID)},
+		{"MODEL", orDash(d.ModelID)},
+		{"A VALUE", strconv.FormatFloat(float64(d.AValue), 'g', -1, 32)},
+		{"MATRIX", size},
+		{"DATE", date(d.Meta)},
+	}
+	return a.out.table(d, []string{"FIELD", "VALUE"}, rows)
+}
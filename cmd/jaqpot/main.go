@@ -0,0 +1,179 @@
+// Command jaqpot is a command line client for Jaqpot.
+//
+// Usage:
+//
+//	jaqpot [-config file] [-profile name] [-url baseURL] [-token token] [-o table|json] <command> <subcommand> [args]
+//
+// Commands:
+//
+//	models list [-min 0] [-max 20] [-org id] [-tag tag]
+//	models get <modelId>
+//	datasets get <datasetId>
+//	features get <featureId>...
+//	tasks get <taskId>
+//	tasks watch <taskId>...
+//	doa get <modelId>
+//	predict -model <modelId> [-in file|-] [-out file|-] [-format csv|ndjson] [-out-format csv|ndjson] [-chunk 100] [-doa] [-quiet]
+//
+// The base URL and token come from a profile of the config file (~/.config/jaqpot/config.yaml),
+// with the JAQPOT_* environment variables and the -url and -token flags applied on top;
+// see package github.com/euclia/gojaqpot/config.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	gojaqpot "github.com/euclia/gojaqpot"
+	"github.com/euclia/gojaqpot/config"
+)
+
+// errUsage is returned for malformed command lines; the usage is printed and the exit status is 2.
+var errUsage = errors.New("usage")
+
+// app is the state shared by every command.
+type app struct {
+	client *gojaqpot.Client
+	token  string
+	out    *printer
+}
+
+// command runs a subcommand with its remaining arguments.
+type command func(a *app, args []string) error
+
+// commands maps "command subcommand" to its implementation.
+var commands = map[string]map[string]command{
+	"models": {
+		"list": modelsList,
+		"get":  modelsGet,
+	},
+	"datasets": {
+		"get": datasetsGet,
+	},
+	"features": {
+		"get": featuresGet,
+	},
+	"tasks": {
+		"get":   tasksGet,
+		"watch": tasksWatch,
+	},
+	"doa": {
+		"get": doaGet,
+	},
+}
+
+// topCommands are the commands without subcommands.
+var topCommands = map[string]command{
+	"predict": predict,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit status.
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("jaqpot", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", config.DefaultPath(), "config file")
+	profileName := flags.String("profile", "", "config profile (default $"+config.EnvProfile+" or the file's current profile)")
+	baseURL := flags.String("url", "", "Jaqpot base URL (overrides the profile and $"+config.EnvBaseURL+")")
+	token := flags.String("token", "", "auth token (overrides the profile and $"+config.EnvToken+")")
+	format := flags.String("o", "table", "output format: table or json")
+	flags.Usage = func() { usage(stderr) }
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(stderr, "jaqpot: unknown output format %q\n", *format)
+		return 2
+	}
+
+	rest := flags.Args()
+	if len(rest) == 0 {
+		usage(stderr)
+		return 2
+	}
+	cmd, ok := topCommands[rest[0]]
+	if ok {
+		rest = rest[1:]
+	} else {
+		if len(rest) < 2 {
+			usage(stderr)
+			return 2
+		}
+		if cmd, ok = commands[rest[0]][rest[1]]; !ok {
+			fmt.Fprintf(stderr, "jaqpot: unknown command %q\n", rest[0]+" "+rest[1])
+			usage(stderr)
+			return 2
+		}
+		rest = rest[2:]
+	}
+
+	file, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "jaqpot: reading config: %s\n", err.Error())
+		return 1
+	}
+	profile, err := file.Profile(*profileName)
+	if err != nil {
+		fmt.Fprintf(stderr, "jaqpot: %s\n", err.Error())
+		return 1
+	}
+	if *baseURL != "" {
+		profile.BaseURL = *baseURL
+	}
+	if *token != "" {
+		profile.Token = *token
+	}
+	authToken, err := profile.ResolveToken()
+	if err == config.ErrNoToken {
+		fmt.Fprintf(stderr, "jaqpot: no auth token for profile %s; set %s or add \"token\", \"tokenEnv\" or \"tokenFile\" to %s\n", profile.Name, config.EnvToken, *configPath)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "jaqpot: %s\n", err.Error())
+		return 1
+	}
+	client, err := gojaqpot.NewClient(profile)
+	if err != nil {
+		fmt.Fprintf(stderr, "jaqpot: %s\n", err.Error())
+		return 1
+	}
+
+	a := &app{
+		client: client,
+		token:  authToken,
+		out:    &printer{w: stdout, json: *format == "json"},
+	}
+	if err := cmd(a, rest); err != nil {
+		if err == errUsage {
+			usage(stderr)
+			return 2
+		}
+		fmt.Fprintf(stderr, "jaqpot: %s\n", err.Error())
+		return 1
+	}
+	return 0
+}
+
+// usage prints the command line summary.
+func usage(w io.Writer) {
+	fmt.Fprint(w, `usage: jaqpot [-config file] [-profile name] [-url baseURL] [-token token] [-o table|json] <command> <subcommand> [args]
+
+commands:
+  models list [-min 0] [-max 20] [-org id] [-tag tag]
+  models get <modelId>
+  datasets get <datasetId>
+  features get <featureId>...
+  tasks get <taskId>
+  tasks watch <taskId>...
+  doa get <modelId>
+  predict -model <modelId> [-in file|-] [-out file|-] [-format csv|ndjson] [-out-format csv|ndjson]
+          [-chunk 100] [-doa] [-quiet]
+`)
+}
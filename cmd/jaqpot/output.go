@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+// printer writes results as aligned tables or as indented JSON.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+// table writes a header and rows, or v as JSON when JSON output was asked for.
+func (p *printer) table(v interface{}, header []string, rows [][]string) error {
+	if p.json {
+		return p.encode(v)
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// encode writes v as indented JSON.
+func (p *printer) encode(v interface{}) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// title returns the first title of an entity, or "-".
+func title(meta models.MetaInfo) string {
+	if len(meta.Titles) == 0 {
+		return "-"
+	}
+	return meta.Titles[0]
+}
+
+// date formats an entity's date, or "-".
+func date(meta models.MetaInfo) string {
+	if meta.Date == nil {
+		return "-"
+	}
+	return meta.Date.Format("2006-01-02 15:04")
+}
+
+// join joins values with commas, or returns "-" when there are none.
+func join(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ",")
+}
+
+// modelRow is the table row of a model.
+func modelRow(m models.Model) []string {
+	return []string{modelID(m), title(m.Meta), m.Algorithm.ID, date(m.Meta), join(m.Meta.Tags)}
+}
+
+// modelHeader is the table header of models.
+var modelHeader = []string{"ID", "TITLE", "ALGORITHM", "DATE", "TAGS"}
+
+// taskRow is the table row of a task.
+func taskRow(t models.Task) []string {
+	return []string{taskID(t), string(t.HasStatus), fmt.Sprintf("%.0f%%", t.PercentageCompleted), t.Type, orDash(t.Result), orDash(t.ErrorReport.Message)}
+}
+
+// taskHeader is the table header of tasks.
+var taskHeader = []string{"ID", "STATUS", "PROGRESS", "TYPE", "RESULT", "ERROR"}
+
+// modelID returns the ID of a model, whichever member it was received in.
+func modelID(m models.Model) string {
+	if m.ID != "" {
+		return m.ID
+	}
+	return orDash(m.SlashID)
+}
+
+// taskID returns the ID of a task, whichever member it was received in.
+func taskID(t models.Task) string {
+	if t.ID != "" {
+		return t.ID
+	}
+	return orDash(t.SlashID)
+}
+
+// orDash returns value, or "-" when it is empty.
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,473 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/euclia/gojaqpot/dataset"
+	"github.com/euclia/gojaqpot/models"
+)
+
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+
+	// defaultChunk is the number of rows sent in one prediction.
+	defaultChunk = 100
+)
+
+// record is an input row: its values by feature name and, for CSV, its cells as read.
+type record struct {
+	cells  []string
+	values map[string]interface{}
+}
+
+// result is the outcome of predicting one input row.
+type result struct {
+	record
+	prediction map[string]interface{}
+	domain     *models.DomainAssessment
+	err        string
+}
+
+// predict reads rows from a CSV or NDJSON file (or stdin), predicts them chunk by chunk and writes
+// every row with its prediction, in input order. Rows that fail are written with an error and make
+// the command exit with a non-zero status.
+func predict(a *app, args []string) error {
+	flags := subFlags("predict")
+	modelID := flags.String("model", "", "model ID")
+	in := flags.String("in", "-", "input file, or - for stdin")
+	out := flags.String("out", "-", "output file, or - for stdout")
+	inFormat := flags.String("format", "", "input format: csv or ndjson (default from the input file extension, else csv)")
+	outFormat := flags.String("out-format", "", "output format: csv or ndjson (default from the output file extension, else the input format)")
+	chunk := flags.Int("chunk", defaultChunk, "rows per prediction")
+	withDOA := flags.Bool("doa", false, "add the domain of applicability of every row")
+	quiet := flags.Bool("quiet", false, "do not show progress")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 || *modelID == "" || *chunk <= 0 {
+		return errUsage
+	}
+
+	if *inFormat == "" {
+		*inFormat = formatFromPath(*in, formatCSV)
+	}
+	if *outFormat == "" {
+		*outFormat = formatFromPath(*out, *inFormat)
+	}
+	if !validFormat(*inFormat) || !validFormat(*outFormat) {
+		return errUsage
+	}
+
+	input := os.Stdin
+	if *in != "-" {
+		file, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	reader, err := newRowReader(*inFormat, input)
+	if err != nil {
+		return err
+	}
+
+	modelSchema, err := a.client.ModelSchema(*modelID, a.token)
+	if err != nil {
+		return err
+	}
+	inputs := make([]string, len(modelSchema.Inputs))
+	for i, input := range modelSchema.Inputs {
+		inputs[i] = input.Name
+	}
+	outputs := make([]string, len(modelSchema.Outputs))
+	for i, output := range modelSchema.Outputs {
+		outputs[i] = output.Name
+	}
+
+	output := os.Stdout
+	if *out != "-" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		output = file
+	}
+	writer := newRowWriter(*outFormat, output, reader.columns(), outputs, *withDOA)
+
+	a.client.C.AttachDOA = *withDOA
+	bar := &progressBar{w: os.Stderr, quiet: *quiet}
+
+	var total, failed int
+	for chunkNo := 1; ; chunkNo++ {
+		rows, readErr := readChunk(reader, *chunk)
+		if readErr != nil && readErr != io.EOF {
+			bar.finish()
+			writer.flush()
+			return fmt.Errorf("reading row %d: %s", total+len(rows)+1, readErr.Error())
+		}
+		if len(rows) > 0 {
+			results := a.predictChunk(*modelID, inputs, rows, func(task models.Task) {
+				bar.update(chunkNo, total, total+len(rows), task.PercentageCompleted)
+			})
+			bar.done(chunkNo, total, total+len(rows))
+			for _, res := range results {
+				if res.err != "" {
+					failed++
+				}
+				if err := writer.write(res); err != nil {
+					return err
+				}
+			}
+			total += len(rows)
+		}
+		if readErr == io.EOF {
+			break
+		}
+	}
+	if err := writer.flush(); err != nil {
+		return err
+	}
+	bar.finish()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d row(s) failed", failed, total)
+	}
+	return nil
+}
+
+// predictChunk predicts a chunk of rows, sending only the values of the model's inputs; the other
+// columns (e.g. an ID or a name) are only written back with the results. If the prediction is refused
+// because of invalid rows, those get their validation errors and the other rows are predicted again;
+// rows of a failed prediction all get its error.
+func (a *app) predictChunk(modelID string, inputs []string, rows []record, onUpdate func(models.Task)) []result {
+	results := make([]result, len(rows))
+	values := make([]map[string]interface{}, len(rows))
+	sent := make([]int, len(rows))
+	for i, row := range rows {
+		results[i].record = row
+		values[i] = inputValues(row.values, inputs)
+		sent[i] = i
+	}
+
+	prediction, err := a.client.PredictWithProgress(modelID, values, onUpdate, a.token)
+	var invalid *dataset.ValidationError
+	if errors.As(err, &invalid) {
+		for _, rowErr := range invalid.Errors {
+			msg := rowErr.Feature + ": " + rowErr.Message
+			if results[rowErr.Row].err != "" {
+				msg = results[rowErr.Row].err + "; " + msg
+			}
+			results[rowErr.Row].err = msg
+		}
+
+		sent, values = sent[:0], nil
+		for i := range results {
+			if results[i].err == "" {
+				sent = append(sent, i)
+				values = append(values, inputValues(rows[i].values, inputs))
+			}
+		}
+		if len(values) == 0 {
+			return results
+		}
+		prediction, err = a.client.PredictWithProgress(modelID, values, onUpdate, a.token)
+	}
+	if err != nil {
+		return failAll(results, err.Error())
+	}
+
+	if len(prediction.Predictions) != len(values) {
+		return failAll(results, fmt.Sprintf("got %d prediction(s) for %d row(s)", len(prediction.Predictions), len(values)))
+	}
+	for j, i := range sent {
+		results[i].prediction = prediction.Predictions[j]
+		if len(prediction.Domain) == len(values) {
+			results[i].domain = &prediction.Domain[j]
+		}
+	}
+	return results
+}
+
+// inputValues returns the values of the given inputs, or all values if the inputs are not known.
+func inputValues(values map[string]interface{}, inputs []string) map[string]interface{} {
+	if len(inputs) == 0 {
+		return values
+	}
+	selected := make(map[string]interface{}, len(inputs))
+	for _, name := range inputs {
+		if value, ok := values[name]; ok {
+			selected[name] = value
+		}
+	}
+	return selected
+}
+
+// failAll sets msg as the error of every row that has none yet.
+func failAll(results []result, msg string) []result {
+	for i := range results {
+		if results[i].err == "" {
+			results[i].err = msg
+		}
+	}
+	return results
+}
+
+// formatFromPath guesses a row format from a file extension.
+func formatFromPath(path string, fallback string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return formatCSV
+	case ".ndjson", ".jsonl":
+		return formatNDJSON
+	}
+	return fallback
+}
+
+// validFormat tells whether format is a known row format.
+func validFormat(format string) bool {
+	return format == formatCSV || format == formatNDJSON
+}
+
+// rowReader reads input rows one at a time.
+type rowReader interface {
+	// read returns the next row, or io.EOF.
+	read() (record, error)
+	// columns returns the CSV header, or nil for NDJSON.
+	columns() []string
+}
+
+// newRowReader creates a reader of the given format; a CSV reader reads its header first.
+func newRowReader(format string, r io.Reader) (rowReader, error) {
+	if format == formatNDJSON {
+		dec := json.NewDecoder(r)
+		dec.UseNumber()
+		return &ndjsonReader{dec: dec}, nil
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("input has no header row")
+		}
+		return nil, err
+	}
+	return &csvReader{reader: reader, header: header}, nil
+}
+
+// readChunk reads up to n rows; it returns io.EOF, possibly with rows, at the end of the input.
+func readChunk(reader rowReader, n int) (rows []record, err error) {
+	for len(rows) < n {
+		row, err := reader.read()
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// csvReader reads rows of a CSV file with a header row. Cells that parse as numbers are numeric
+// values, empty cells are left out and other cells are nominal values.
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+func (r *csvReader) read() (row record, err error) {
+	cells, err := r.reader.Read()
+	if err != nil {
+		return row, err
+	}
+	row = record{cells: cells, values: make(map[string]interface{}, len(cells))}
+	for i, cell := range cells {
+		if i >= len(r.header) || cell == "" {
+			continue
+		}
+		if number, err := strconv.ParseFloat(cell, 64); err == nil {
+			row.values[r.header[i]] = number
+		} else {
+			row.values[r.header[i]] = cell
+		}
+	}
+	return row, nil
+}
+
+func (r *csvReader) columns() []string {
+	return r.header
+}
+
+// ndjsonReader reads rows given as one JSON object per line.
+type ndjsonReader struct {
+	dec *json.Decoder
+}
+
+func (r *ndjsonReader) read() (row record, err error) {
+	if err = r.dec.Decode(&row.values); err != nil {
+		return row, err
+	}
+	if row.values == nil {
+		return row, fmt.Errorf("expected a JSON object")
+	}
+	return row, nil
+}
+
+func (r *ndjsonReader) columns() []string {
+	return nil
+}
+
+// rowWriter writes prediction results one row at a time.
+type rowWriter interface {
+	write(res result) error
+	flush() error
+}
+
+// newRowWriter creates a writer of the given format. inputs are the input columns, nil for NDJSON input.
+func newRowWriter(format string, w io.Writer, inputs []string, outputs []string, withDOA bool) rowWriter {
+	if format == formatNDJSON {
+		return &ndjsonWriter{enc: json.NewEncoder(w), withDOA: withDOA}
+	}
+	return &csvWriter{writer: csv.NewWriter(w), inputs: inputs, outputs: outputs, withDOA: withDOA}
+}
+
+// csvWriter writes the input columns, then a column per model output, the DOA columns and an error column.
+type csvWriter struct {
+	writer        *csv.Writer
+	inputs        []string
+	outputs       []string
+	withDOA       bool
+	headerWritten bool
+}
+
+func (w *csvWriter) write(res result) error {
+	if !w.headerWritten {
+		// Input columns of NDJSON rows are taken from the first row.
+		if w.inputs == nil {
+			for key := range res.values {
+				w.inputs = append(w.inputs, key)
+			}
+			sort.Strings(w.inputs)
+		}
+		header := append(append([]string{}, w.inputs...), w.outputs...)
+		if w.withDOA {
+			header = append(header, "in_domain", "leverage")
+		}
+		if err := w.writer.Write(append(header, "error")); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	line := make([]string, 0, len(w.inputs)+len(w.outputs)+3)
+	for i, column := range w.inputs {
+		if res.cells != nil {
+			if i < len(res.cells) {
+				line = append(line, res.cells[i])
+			} else {
+				line = append(line, "")
+			}
+			continue
+		}
+		line = append(line, cell(res.values[column]))
+	}
+	for _, column := range w.outputs {
+		line = append(line, cell(res.prediction[column]))
+	}
+	if w.withDOA {
+		if res.domain != nil {
+			line = append(line, strconv.FormatBool(res.domain.InDomain), strconv.FormatFloat(res.domain.Leverage, 'g', 6, 64))
+		} else {
+			line = append(line, "", "")
+		}
+	}
+	return w.writer.Write(append(line, res.err))
+}
+
+func (w *csvWriter) flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// cell formats a value as a CSV cell; missing values are empty.
+func cell(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'g', -1, 64)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// ndjsonWriter writes every row as a JSON object holding its input, prediction, DOA and error.
+type ndjsonWriter struct {
+	enc     *json.Encoder
+	withDOA bool
+}
+
+func (w *ndjsonWriter) write(res result) error {
+	line := struct {
+		Input      map[string]interface{}   `json:"input"`
+		Prediction map[string]interface{}   `json:"prediction,omitempty"`
+		Domain     *models.DomainAssessment `json:"domain,omitempty"`
+		Error      string                   `json:"error,omitempty"`
+	}{Input: res.values, Prediction: res.prediction, Error: res.err}
+	if w.withDOA {
+		line.Domain = res.domain
+	}
+	return w.enc.Encode(line)
+}
+
+func (w *ndjsonWriter) flush() error {
+	return nil
+}
+
+// progressBar shows the task percentage of the chunk being predicted on one terminal line.
+type progressBar struct {
+	w     io.Writer
+	quiet bool
+	shown bool
+}
+
+// barWidth is the number of cells of the progress bar.
+const barWidth = 30
+
+func (p *progressBar) update(chunk int, from int, to int, percentage float32) {
+	if p.quiet {
+		return
+	}
+	if percentage < 0 {
+		percentage = 0
+	} else if percentage > 100 {
+		percentage = 100
+	}
+	filled := int(percentage) * barWidth / 100
+	fmt.Fprintf(p.w, "\rchunk %d (rows %d-%d) [%s%s] %3.0f%%", chunk, from+1, to,
+		strings.Repeat("#", filled), strings.Repeat(".", barWidth-filled), percentage)
+	p.shown = true
+}
+
+// done shows a chunk as finished, whether or not its task reported progress.
+func (p *progressBar) done(chunk int, from int, to int) {
+	p.update(chunk, from, to, 100)
+}
+
+// finish ends the progress line.
+func (p *progressBar) finish() {
+	if p.shown {
+		fmt.Fprintln(p.w)
+	}
+}
@@ -0,0 +1,384 @@
+// Package config loads Jaqpot connection profiles from a config file and the environment.
+//
+// A config file (by default ~/.config/jaqpot/config.yaml) holds named profiles:
+//
+//	current: dev
+//	timeout: 30s
+//	profiles:
+//	  dev:
+//	    baseUrl: http://localhost:8080/
+//	    tokenFile: ~/.config/jaqpot/dev.token
+//	  prod:
+//	    baseUrl: https://api.jaqpot.org/
+//	    servicePrefix: jaqpot/services/
+//	    tokenEnv: JAQPOT_PROD_TOKEN
+//	    proxy: http://proxy.example.org:3128
+//
+// Settings at the top level apply to every profile that does not set them.
+// The JAQPOT_* environment variables override the settings of the selected profile.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the Jaqpot deployment used when a profile sets none.
+	DefaultBaseURL = "https://api.jaqpot.org/"
+
+	// DefaultServicePrefix is the path of the Jaqpot services under the base URL.
+	DefaultServicePrefix = "jaqpot/services/"
+
+	// DefaultProfile is the profile used when none is selected.
+	DefaultProfile = "default"
+)
+
+// Environment variables overriding the config file.
+const (
+	EnvProfile       = "JAQPOT_PROFILE"
+	EnvConfig        = "JAQPOT_CONFIG"
+	EnvBaseURL       = "JAQPOT_URL"
+	EnvServicePrefix = "JAQPOT_SERVICE_PREFIX"
+	EnvToken         = "JAQPOT_TOKEN"
+	EnvTimeout       = "JAQPOT_TIMEOUT"
+	EnvProxy         = "JAQPOT_PROXY"
+)
+
+// ErrNoToken is returned by Profile.ResolveToken when the profile has no token source.
+var ErrNoToken = errors.New("no auth token configured")
+
+// Profile is the configuration of one Jaqpot deployment.
+type Profile struct {
+	Name    string
+	BaseURL string
+	// ServicePrefix is the path of the services under BaseURL, when it is not DefaultServicePrefix.
+	ServicePrefix string
+	// The token is Token, else the content of the environment variable TokenEnv, else the content of TokenFile.
+	Token     string
+	TokenEnv  string
+	TokenFile string
+	// Timeout limits every request; zero keeps the client's default.
+	Timeout time.Duration
+	// Proxy is the URL of an HTTP proxy; empty uses the HTTP_PROXY/HTTPS_PROXY environment variables.
+	Proxy string
+}
+
+// File is a parsed config file.
+type File struct {
+	// Current is the profile used when none is selected.
+	Current  string
+	Defaults Profile
+	Profiles map[string]Profile
+}
+
+// DefaultPath returns the path of the config file: $JAQPOT_CONFIG, else jaqpot/config.yaml in
+// $XDG_CONFIG_HOME or ~/.config. The same path is used on every OS, rather than os.UserConfigDir's
+// Application Support or AppData.
+func DefaultPath() string {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(dir) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "jaqpot", "config.yaml")
+}
+
+// Load reads a config file. A missing file is an empty config, so profiles can come from the environment alone.
+func Load(path string) (file File, err error) {
+	if path == "" {
+		return file, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return file, nil
+		}
+		return file, err
+	}
+	file, err = Parse(data)
+	if err != nil {
+		return file, fmt.Errorf("%s: %s", path, err.Error())
+	}
+	return file, nil
+}
+
+// Parse parses the content of a config file.
+func Parse(data []byte) (file File, err error) {
+	doc, err := parseYAML(data)
+	if err != nil {
+		return file, err
+	}
+
+	for key, value := range doc {
+		switch key {
+		case "current":
+			if file.Current, err = stringValue(key, value); err != nil {
+				return file, err
+			}
+		case "profiles":
+			profiles, ok := value.(map[string]node)
+			if !ok {
+				if value == "" {
+					continue
+				}
+				return file, errors.New("profiles must be a mapping of profile names")
+			}
+			file.Profiles = make(map[string]Profile, len(profiles))
+			for name, settings := range profiles {
+				mapping, ok := settings.(map[string]node)
+				if !ok && settings != "" {
+					return file, fmt.Errorf("profile %s must be a mapping", name)
+				}
+				profile := Profile{Name: name}
+				for key, value := range mapping {
+					if err = profile.set(key, value); err != nil {
+						return file, fmt.Errorf("profile %s: %s", name, err.Error())
+					}
+				}
+				file.Profiles[name] = profile
+			}
+		default:
+			if err = file.Defaults.set(key, value); err != nil {
+				return file, err
+			}
+		}
+	}
+	return file, nil
+}
+
+// set sets a profile setting from the config file.
+func (p *Profile) set(key string, value node) (err error) {
+	text, err := stringValue(key, value)
+	if err != nil {
+		return err
+	}
+	switch key {
+	case "baseUrl", "baseURL", "url":
+		p.BaseURL = text
+	case "servicePrefix":
+		p.ServicePrefix = text
+	case "token":
+		p.Token = text
+	case "tokenEnv":
+		p.TokenEnv = text
+	case "tokenFile":
+		p.TokenFile = text
+	case "timeout":
+		p.Timeout, err = parseTimeout(text)
+	case "proxy":
+		p.Proxy = text
+	default:
+		return fmt.Errorf("unknown setting %q", key)
+	}
+	return err
+}
+
+// stringValue returns a scalar setting, or an error for a mapping.
+func stringValue(key string, value node) (string, error) {
+	text, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a single value", key)
+	}
+	return text, nil
+}
+
+// parseTimeout parses a duration such as "30s", or a number of seconds.
+func parseTimeout(text string) (time.Duration, error) {
+	if text == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.ParseFloat(text, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	timeout, err := time.ParseDuration(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q", text)
+	}
+	return timeout, nil
+}
+
+// Names returns the names of the profiles, sorted.
+func (f File) Names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns a profile with the file's defaults and the environment overrides applied.
+// An empty name selects $JAQPOT_PROFILE, else the file's current profile, else "default".
+// Asking for a profile the file does not define is an error, except for the default profile.
+func (f File) Profile(name string) (profile Profile, err error) {
+	if name == "" {
+		name = os.Getenv(EnvProfile)
+	}
+	if name == "" {
+		name = f.Current
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	profile, ok := f.Profiles[name]
+	if !ok && name != DefaultProfile {
+		return profile, fmt.Errorf("unknown profile %q (known: %s)", name, strings.Join(f.Names(), ", "))
+	}
+	profile.Name = name
+	profile.inherit(f.Defaults)
+
+	if err = profile.applyEnv(); err != nil {
+		return profile, err
+	}
+	if profile.BaseURL == "" {
+		profile.BaseURL = DefaultBaseURL
+	}
+	return profile, nil
+}
+
+// inherit fills the settings the profile leaves empty from defaults.
+func (p *Profile) inherit(defaults Profile) {
+	if p.BaseURL == "" {
+		p.BaseURL = defaults.BaseURL
+	}
+	if p.ServicePrefix == "" {
+		p.ServicePrefix = defaults.ServicePrefix
+	}
+	if p.Token == "" && p.TokenEnv == "" && p.TokenFile == "" {
+		p.Token, p.TokenEnv, p.TokenFile = defaults.Token, defaults.TokenEnv, defaults.TokenFile
+	}
+	if p.Timeout == 0 {
+		p.Timeout = defaults.Timeout
+	}
+	if p.Proxy == "" {
+		p.Proxy = defaults.Proxy
+	}
+}
+
+// applyEnv applies the JAQPOT_* environment overrides.
+func (p *Profile) applyEnv() (err error) {
+	if value := os.Getenv(EnvBaseURL); value != "" {
+		p.BaseURL = value
+	}
+	if value := os.Getenv(EnvServicePrefix); value != "" {
+		p.ServicePrefix = value
+	}
+	if value := os.Getenv(EnvToken); value != "" {
+		p.Token = value
+	}
+	if value := os.Getenv(EnvTimeout); value != "" {
+		if p.Timeout, err = parseTimeout(value); err != nil {
+			return fmt.Errorf("%s: %s", EnvTimeout, err.Error())
+		}
+	}
+	if value := os.Getenv(EnvProxy); value != "" {
+		p.Proxy = value
+	}
+	return nil
+}
+
+// ResolveToken returns the profile's auth token from its token source.
+func (p Profile) ResolveToken() (token string, err error) {
+	if p.Token != "" {
+		return p.Token, nil
+	}
+	if p.TokenEnv != "" {
+		if token = os.Getenv(p.TokenEnv); token != "" {
+			return token, nil
+		}
+		if p.TokenFile == "" {
+			return "", fmt.Errorf("profile %s: environment variable %s is empty", p.Name, p.TokenEnv)
+		}
+	}
+	if p.TokenFile != "" {
+		data, err := ioutil.ReadFile(expandHome(p.TokenFile))
+		if err != nil {
+			return "", fmt.Errorf("profile %s: reading token: %s", p.Name, err.Error())
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return "", ErrNoToken
+}
+
+// NormalizedBaseURL returns the base URL ending with a slash, as the client expects it.
+func (p Profile) NormalizedBaseURL() string {
+	if strings.HasSuffix(p.BaseURL, "/") {
+		return p.BaseURL
+	}
+	return p.BaseURL + "/"
+}
+
+// HTTPClient creates an HTTP client with the profile's timeout, proxy and service prefix.
+func (p Profile) HTTPClient() (client *http.Client, err error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if p.Proxy != "" {
+		proxyURL, err := url.Parse(p.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: invalid proxy: %s", p.Name, err.Error())
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	client = &http.Client{Transport: transport, Timeout: p.Timeout}
+
+	prefix := strings.Trim(p.ServicePrefix, "/")
+	if prefix != "" && prefix+"/" != DefaultServicePrefix {
+		base, err := url.Parse(p.NormalizedBaseURL())
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: invalid base URL: %s", p.Name, err.Error())
+		}
+		client.Transport = &prefixTransport{
+			from: base.Path + DefaultServicePrefix,
+			to:   base.Path + prefix + "/",
+			next: transport,
+		}
+	}
+	return client, nil
+}
+
+// prefixTransport moves requests for the default service prefix to the profile's one.
+type prefixTransport struct {
+	from string
+	to   string
+	next http.RoundTripper
+}
+
+// RoundTrip rewrites the request path and sends it on.
+func (t *prefixTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.HasPrefix(req.URL.Path, t.from) {
+		return t.next.RoundTrip(req)
+	}
+	moved := req.Clone(req.Context())
+	moved.URL.Path = t.to + strings.TrimPrefix(req.URL.Path, t.from)
+	moved.URL.RawPath = ""
+	return t.next.RoundTrip(moved)
+}
+
+// expandHome replaces a leading ~ with the user's home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// setenv sets an environment variable for the duration of a test.
+func setenv(t *testing.T, key string, value string) {
+	t.Helper()
+	previous, had := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if had {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+const testConfig = `
+current: dev
+timeout: 30s
+tokenEnv: JAQPOT_TEST_TOKEN
+profiles:
+  dev:
+    baseUrl: http://localhost:8080
+  prod:
+    baseUrl: https://api.jaqpot.org/
+    timeout: 90
+    token: prod-token
+`
+
+func TestProfile(t *testing.T) {
+	for _, key := range []string{EnvProfile, EnvBaseURL, EnvServicePrefix, EnvToken, EnvTimeout, EnvProxy} {
+		setenv(t, key, "")
+	}
+	file, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := file.Profile("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Name != "dev" || dev.NormalizedBaseURL() != "http://localhost:8080/" || dev.Timeout != 30*time.Second || dev.TokenEnv != "JAQPOT_TEST_TOKEN" {
+		t.Errorf("current profile = %+v, want dev with the top-level defaults", dev)
+	}
+
+	prod, err := file.Profile("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prod.Timeout != 90*time.Second || prod.Token != "prod-token" || prod.TokenEnv != "" {
+		t.Errorf("prod profile = %+v, want its own timeout and token", prod)
+	}
+
+	setenv(t, EnvProfile, "prod")
+	setenv(t, EnvToken, "env-token")
+	if prod, err = file.Profile(""); err != nil {
+		t.Fatal(err)
+	}
+	if prod.Name != "prod" || prod.Token != "env-token" {
+		t.Errorf("profile = %+v, want prod with the token from %s", prod, EnvToken)
+	}
+
+	if _, err = file.Profile("staging"); err == nil {
+		t.Error("no error for an unknown profile")
+	}
+	setenv(t, EnvProfile, "")
+	if def, err := (File{}).Profile(""); err != nil || def.BaseURL != DefaultBaseURL {
+		t.Errorf("default profile = %+v, %v; want the default base URL", def, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, data := range []string{
+		"colour: blue\n",
+		"timeout: soon\n",
+		"profiles: dev\n",
+		"profiles:\n  dev:\n    baseUrl:\n      host: x\n",
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%q) succeeded", data)
+		}
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	home := t.TempDir()
+	setenv(t, "HOME", home)
+	setenv(t, "USERPROFILE", home)
+	setenv(t, "XDG_CONFIG_HOME", "")
+	setenv(t, EnvConfig, "")
+
+	if got, want := DefaultPath(), filepath.Join(home, ".config", "jaqpot", "config.yaml"); got != want {
+		t.Errorf("DefaultPath = %q, want %q", got, want)
+	}
+
+	xdg := t.TempDir()
+	setenv(t, "XDG_CONFIG_HOME", xdg)
+	if got, want := DefaultPath(), filepath.Join(xdg, "jaqpot", "config.yaml"); got != want {
+		t.Errorf("DefaultPath = %q, want %q", got, want)
+	}
+
+	setenv(t, EnvConfig, "jaqpot.yaml")
+	if got := DefaultPath(); got != "jaqpot.yaml" {
+		t.Errorf("DefaultPath = %q, want $%s", got, EnvConfig)
+	}
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// node is a parsed YAML value: a string, or a map[string]node for a mapping.
+type node interface{}
+
+// yamlLine is a significant line of a YAML document.
+type yamlLine struct {
+	number int
+	indent int
+	key    string
+	value  string
+}
+
+// parseYAML parses the subset of YAML used by config files: nested block mappings of
+// plain, single-quoted or double-quoted scalars, with # comments. Sequences, flow
+// collections, anchors and multi-line scalars are not supported.
+func parseYAML(data []byte) (doc map[string]node, err error) {
+	var lines []yamlLine
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", number)
+		}
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			return nil, fmt.Errorf("line %d: sequences are not supported", number)
+		}
+
+		colon := keyEnd(trimmed)
+		if colon < 0 {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", number)
+		}
+		key, err := unquote(strings.TrimSpace(trimmed[:colon]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", number, err.Error())
+		}
+		value, err := scalar(strings.TrimSpace(trimmed[colon+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", number, err.Error())
+		}
+		lines = append(lines, yamlLine{number: number, indent: len(text) - len(trimmed), key: key, value: value})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	doc, rest, err := parseMapping(lines, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("line %d: unexpected indentation", rest[0].number)
+	}
+	return doc, nil
+}
+
+// parseMapping parses the lines of a mapping indented by indent, returning the lines that follow it.
+func parseMapping(lines []yamlLine, indent int) (mapping map[string]node, rest []yamlLine, err error) {
+	mapping = make(map[string]node)
+	for len(lines) > 0 {
+		line := lines[0]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+		}
+		if _, ok := mapping[line.key]; ok {
+			return nil, nil, fmt.Errorf("line %d: duplicate key %q", line.number, line.key)
+		}
+		lines = lines[1:]
+
+		// A key without a value opens a nested mapping when the next line is indented further.
+		if line.value == "" && len(lines) > 0 && lines[0].indent > indent {
+			var nested map[string]node
+			if nested, lines, err = parseMapping(lines, lines[0].indent); err != nil {
+				return nil, nil, err
+			}
+			mapping[line.key] = nested
+			continue
+		}
+		mapping[line.key] = line.value
+	}
+	return mapping, lines, nil
+}
+
+// keyEnd returns the index of the colon ending the key of a line, or -1.
+func keyEnd(line string) int {
+	if line[0] == '"' || line[0] == '\'' {
+		end := closingQuote(line)
+		if end < 0 || end+1 >= len(line) || line[end+1] != ':' {
+			return -1
+		}
+		return end + 1
+	}
+	for i := 0; i < len(line); i++ {
+		if line[i] == ':' && (i+1 == len(line) || line[i+1] == ' ') {
+			return i
+		}
+	}
+	return -1
+}
+
+// scalar decodes the value part of a line, dropping a trailing comment.
+func scalar(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if value[0] == '"' || value[0] == '\'' {
+		end := closingQuote(value)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+		if trailing := strings.TrimSpace(value[end+1:]); trailing != "" && !strings.HasPrefix(trailing, "#") {
+			return "", fmt.Errorf("unexpected text after quoted value")
+		}
+		return unquote(value[:end+1])
+	}
+	if value[0] == '[' || value[0] == '{' || value[0] == '&' || value[0] == '*' || value[0] == '|' || value[0] == '>' {
+		return "", fmt.Errorf("unsupported value %q", value)
+	}
+	if comment := strings.Index(value, " #"); comment >= 0 {
+		value = strings.TrimSpace(value[:comment])
+	}
+	if value == "~" || value == "null" {
+		return "", nil
+	}
+	return value, nil
+}
+
+// closingQuote returns the index of the quote closing the quoted string s starts with, or -1.
+func closingQuote(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case quote == '\'' && s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// unquote decodes a quoted string, or returns s unchanged if it is not quoted.
+func unquote(s string) (string, error) {
+	if len(s) < 2 {
+		return s, nil
+	}
+	switch s[0] {
+	case '"':
+		return strconv.Unquote(s)
+	case '\'':
+		return strings.Replace(s[1:len(s)-1], "''", "'", -1), nil
+	}
+	return s, nil
+}
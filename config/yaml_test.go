@@ -0,0 +1,97 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	data := `
+# Jaqpot profiles
+---
+current: dev   # the profile used by default
+timeout: 30s
+empty:
+nothing: ~
+profiles:
+  dev:
+    baseUrl: http://localhost:8080/
+    tokenFile: '~/.config/jaqpot/dev''s.token'
+  "prod env":
+    baseUrl: "https://api.jaqpot.org/"
+    token: "a \"quoted\" token # not a comment"
+    nested:
+        deeper: yes
+
+  local:
+last: value:with:colons
+`
+	doc, err := parseYAML([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]node{
+		"current": "dev",
+		"timeout": "30s",
+		"empty":   "",
+		"nothing": "",
+		"profiles": map[string]node{
+			"dev": map[string]node{
+				"baseUrl":   "http://localhost:8080/",
+				"tokenFile": "~/.config/jaqpot/dev's.token",
+			},
+			"prod env": map[string]node{
+				"baseUrl": "https://api.jaqpot.org/",
+				"token":   `a "quoted" token # not a comment`,
+				"nested":  map[string]node{"deeper": "yes"},
+			},
+			"local": "",
+		},
+		"last": "value:with:colons",
+	}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("parseYAML =\n%#v\nwant\n%#v", doc, want)
+	}
+}
+
+func TestParseYAMLCRLF(t *testing.T) {
+	doc, err := parseYAML([]byte("current: dev\r\nprofiles:\r\n  dev:\r\n    token: abc\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	profiles := doc["profiles"].(map[string]node)
+	if token := profiles["dev"].(map[string]node)["token"]; token != "abc" {
+		t.Errorf("token = %q, want abc", token)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"tab indentation", "profiles:\n\tdev: x\n", "line 2: tabs"},
+		{"sequence", "profiles:\n  - dev\n", "line 2: sequences"},
+		{"no colon", "current dev\n", "line 1: expected"},
+		{"duplicate key", "current: a\ncurrent: b\n", "line 2: duplicate key \"current\""},
+		{"unexpected indentation", "current: a\n  timeout: 3\n", "line 2: unexpected indentation"},
+		{"dedent into nowhere", "profiles:\n    dev:\n      token: a\n  prod:\n", "line 4: unexpected indentation"},
+		{"unterminated quote", "token: \"abc\n", "line 1: unterminated"},
+		{"text after quote", "token: 'abc' def\n", "line 1: unexpected text"},
+		{"flow collection", "profiles: {dev: {}}\n", "line 1: unsupported value"},
+		{"anchor", "defaults: &base\n", "line 1: unsupported value"},
+		{"block scalar", "token: |\n", "line 1: unsupported value"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseYAML([]byte(test.data))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error = %v, want one containing %q", err, test.want)
+			}
+		})
+	}
+}
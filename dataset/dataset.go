@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -33,7 +32,6 @@ func GetDataset(datasetID string, AuthToken string, BaseURL string, HTTPClient *
 	var returnData models.Dataset
 
 	if err != nil {
-		fmt.Printf(err.Error())
 		return returnData, err
 	}
 
@@ -41,7 +39,7 @@ func GetDataset(datasetID string, AuthToken string, BaseURL string, HTTPClient *
 		var errorReport models.ErrorReport
 		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
 		defer resp.Body.Close()
-		err = errors.New(errorReport.Message)
+		err = models.NewAPIError(resp.StatusCode, errorReport)
 		return returnData, err
 	}
 	defer resp.Body.Close()
@@ -50,12 +48,47 @@ func GetDataset(datasetID string, AuthToken string, BaseURL string, HTTPClient *
 	return returnData, err
 }
 
+// GetMyDatasets is a method to get a list of user's datasets (without their data entries).
+func GetMyDatasets(min int, max int, AuthToken string, BaseURL string, HTTPClient *http.Client) (myDatasets models.Datasets, err error) {
+	var endpoint = BaseURL + datasetPath
+	req, err := http.NewRequest("GET", endpoint, nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+AuthToken)
+
+	q := req.URL.Query()
+	q.Add("min", strconv.Itoa(min))
+	q.Add("max", strconv.Itoa(max))
+
+	req.URL.RawQuery = q.Encode()
+	resp, err := HTTPClient.Do(req)
+	var returnDatasets models.Datasets
+
+	if err != nil {
+		return returnDatasets, err
+	}
+
+	if resp.StatusCode != 200 {
+		var errorReport models.ErrorReport
+		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
+		defer resp.Body.Close()
+		err = models.NewAPIError(resp.StatusCode, errorReport)
+		return returnDatasets, err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&returnDatasets.Datasets)
+
+	returnDatasets.Total, _ = strconv.Atoi(resp.Header.Get("Total"))
+
+	return returnDatasets, err
+}
+
 // PostDataset is a method to post a Jaqpot Dataset.
 func PostDataset(data models.Dataset, AuthToken string, BaseURL string, HTTPClient *http.Client) (SlashID string, err error) {
 	var endpoint = BaseURL + datasetPath
 	body, err := json.Marshal(data)
 	if err != nil {
-		fmt.Printf(err.Error())
 		return
 	}
 
@@ -70,36 +103,58 @@ func PostDataset(data models.Dataset, AuthToken string, BaseURL string, HTTPClie
 	var returnData models.Dataset
 
 	if err != nil {
-		fmt.Printf(err.Error())
 		return returnID, err
 	}
 
+	if resp.StatusCode >= 300 {
+		var errorReport models.ErrorReport
+		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
+		defer resp.Body.Close()
+		err = models.NewAPIError(resp.StatusCode, errorReport)
+		return returnID, err
+	}
 	defer resp.Body.Close()
 
 	err = json.NewDecoder(resp.Body).Decode(&returnData)
 
-	var currList = strings.Split(resp.Header["Location"][0], "/")
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return returnID, errors.New("dataset created without a Location header")
+	}
+	var currList = strings.Split(strings.TrimRight(location, "/"), "/")
 	returnID = currList[len(currList)-1]
 	return returnID, err
 }
 
 // CreateDataset is a method to create a Dataset object (used for the predict method).
-func CreateDataset(modelID string, values []map[string]interface{}, AuthToken string, BaseURL string, HTTPClient *http.Client) (dataset models.Dataset) {
+// The values are validated against the model's independent features first; see Validate.
+// A feature definition that cannot be fetched does not block the dataset: its values are only checked for presence.
+func CreateDataset(modelID string, values []map[string]interface{}, AuthToken string, BaseURL string, HTTPClient *http.Client) (dataset models.Dataset, err error) {
 	var info models.FeatureInfo
 	var returnData models.Dataset
 
 	var cnt = 0
 	reverse := make(map[string]string)
-	currentModel, _ := model.GetModel(modelID, AuthToken, BaseURL, HTTPClient)
+	currentModel, err := model.GetModel(modelID, AuthToken, BaseURL, HTTPClient)
+	if err != nil {
+		return returnData, err
+	}
+
+	independentFeatures, err := independentFeatures(currentModel)
+	if err != nil {
+		return returnData, err
+	}
 
-	var independentFeatures = currentModel.AdditionalInfo.(map[string]interface{})["independentFeatures"]
+	if err = Validate(independentFeatures, getFeatures(independentFeatures, AuthToken, BaseURL, HTTPClient), values); err != nil {
+		return returnData, err
+	}
 
-	for index, value := range independentFeatures.(map[string]interface{}) {
+	for index, value := range independentFeatures {
 
 		// Dynamically add a sub-map
 		info.URI = index
 		info.Key = strconv.Itoa(cnt)
-		info.Name = fmt.Sprintf("%v", value)
+		info.Name = value
 		reverse[info.Name] = strconv.Itoa(cnt)
 
 		// The slice grows as needed.
@@ -128,5 +183,5 @@ func CreateDataset(modelID string, values []map[string]interface{}, AuthToken st
 		cnt++
 
 	}
-	return returnData
+	return returnData, nil
 }
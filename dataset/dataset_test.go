@@ -0,0 +1,57 @@
+package dataset
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+func TestPostDataset(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		location string
+		body     string
+		wantID   string
+		wantCode int
+	}{
+		{"created", http.StatusCreated, "https://api.jaqpot.org/jaqpot/services/dataset/d1", `{"_id":"d1"}`, "d1", 0},
+		{"forbidden", http.StatusForbidden, "", `{"message":"not allowed","httpStatus":403}`, "", http.StatusForbidden},
+		{"server error", http.StatusInternalServerError, "", ``, "", http.StatusInternalServerError},
+		{"no location", http.StatusCreated, "", `{"_id":"d1"}`, "", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if test.location != "" {
+					w.Header().Set("Location", test.location)
+				}
+				w.WriteHeader(test.status)
+				w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			id, err := PostDataset(models.Dataset{}, "token", server.URL+"/", server.Client())
+			if id != test.wantID {
+				t.Errorf("ID = %q, want %q", id, test.wantID)
+			}
+			var apiErr *models.APIError
+			switch {
+			case test.wantCode != 0:
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != test.wantCode {
+					t.Errorf("error = %v, want an APIError with status %d", err, test.wantCode)
+				}
+			case test.wantID == "":
+				if err == nil {
+					t.Error("no error for a dataset created without a Location header")
+				}
+			case err != nil:
+				t.Errorf("error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package dataset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/euclia/gojaqpot/feature"
+	"github.com/euclia/gojaqpot/model"
+	"github.com/euclia/gojaqpot/models"
+)
+
+// RowError describes a problem found in a single row of prediction inputs.
+type RowError struct {
+	Row     int
+	Feature string
+	Message string
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %d: feature %q: %s", e.Row, e.Feature, e.Message)
+}
+
+// ValidationError holds every RowError found while validating prediction inputs.
+type ValidationError struct {
+	Errors []RowError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, rowErr := range e.Errors {
+		msgs[i] = rowErr.Error()
+	}
+	return fmt.Sprintf("%d invalid input value(s): %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// ValidateValues is a method to check prediction inputs against a model's independent features without uploading them.
+func ValidateValues(modelID string, values []map[string]interface{}, AuthToken string, BaseURL string, HTTPClient *http.Client) (err error) {
+	currentModel, err := model.GetModel(modelID, AuthToken, BaseURL, HTTPClient)
+	if err != nil {
+		return err
+	}
+
+	independentFeatures, err := independentFeatures(currentModel)
+	if err != nil {
+		return err
+	}
+
+	return Validate(independentFeatures, getFeatures(independentFeatures, AuthToken, BaseURL, HTTPClient), values)
+}
+
+// Validate checks prediction inputs against independent features (URI to name) and their definitions (keyed by URI).
+// It reports missing features, unexpected keys, null values, wrong value types and inadmissible nominal values per row.
+// Features without a definition in features are only checked for missing and null values.
+func Validate(independentFeatures map[string]string, features map[string]models.Feature, values []map[string]interface{}) (err error) {
+	var report ValidationError
+	byName := make(map[string]*models.Feature)
+	names := make([]string, 0, len(independentFeatures))
+
+	for uri, name := range independentFeatures {
+		byName[name] = nil
+		if feat, ok := features[uri]; ok {
+			byName[name] = &feat
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for row, item := range values {
+		for _, name := range names {
+			if _, ok := item[name]; !ok {
+				report.Errors = append(report.Errors, RowError{Row: row, Feature: name, Message: "missing value"})
+			}
+		}
+
+		keys := make([]string, 0, len(item))
+		for key := range item {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			feat, ok := byName[key]
+			if !ok {
+				report.Errors = append(report.Errors, RowError{Row: row, Feature: key, Message: "not an independent feature of the model"})
+				continue
+			}
+			if msg := checkValue(feat, item[key]); msg != "" {
+				report.Errors = append(report.Errors, RowError{Row: row, Feature: key, Message: msg})
+			}
+		}
+	}
+
+	if len(report.Errors) > 0 {
+		return &report
+	}
+	return nil
+}
+
+// checkValue returns a description of what is wrong with value, or an empty string if it is acceptable.
+// Only null values are reported for a feature without a definition.
+func checkValue(feat *models.Feature, value interface{}) string {
+	if value == nil {
+		return "value is null"
+	}
+	if feat == nil {
+		return ""
+	}
+
+	if feat.Nominal() {
+		switch value.(type) {
+		case string, json.Number, float32, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		default:
+			return fmt.Sprintf("expected a nominal value, got %T", value)
+		}
+		if len(feat.AdmissibleValues) == 0 {
+			return ""
+		}
+		str := fmt.Sprintf("%v", value)
+		for _, admissible := range feat.AdmissibleValues {
+			if str == admissible {
+				return ""
+			}
+		}
+		return fmt.Sprintf("value %q is not one of %v", str, feat.AdmissibleValues)
+	}
+
+	if _, err := models.ToFloat(value); err == nil {
+		return ""
+	}
+	return fmt.Sprintf("expected a numeric value, got %T", value)
+}
+
+// independentFeatures returns the model's independent features, mapping each feature URI to its name.
+func independentFeatures(currentModel models.Model) (map[string]string, error) {
+	info, err := currentModel.DecodeAdditionalInfo()
+	if err != nil {
+		return nil, err
+	}
+	if len(info.IndependentFeatures) == 0 {
+		return nil, errors.New("model additional info has no independent features")
+	}
+	return info.IndependentFeatures, nil
+}
+
+// getFeatures fetches the definition of every feature URI, keyed by URI. Definitions that cannot be
+// fetched (e.g. of a deleted feature) are left out, so that only the keys and nulls of their values are checked.
+func getFeatures(independentFeatures map[string]string, AuthToken string, BaseURL string, HTTPClient *http.Client) map[string]models.Feature {
+	uris := make([]string, 0, len(independentFeatures))
+	for uri := range independentFeatures {
+		uris = append(uris, uri)
+	}
+
+	features, _ := feature.GetFeatureMap(uris, AuthToken, BaseURL, HTTPClient)
+	return features
+}
@@ -0,0 +1,50 @@
+package dataset
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+func TestValidate(t *testing.T) {
+	independentFeatures := map[string]string{
+		"feature/mw":    "MW",
+		"feature/class": "Class",
+		"feature/label": "Label",
+		"feature/gone":  "Gone",
+	}
+	features := map[string]models.Feature{
+		"feature/mw":    {},
+		"feature/class": {AdmissibleValues: []string{"active", "inactive"}},
+		"feature/label": {OntologicalClasses: []string{"ot:NominalFeature"}},
+	}
+	values := []map[string]interface{}{
+		{"MW": 180.16, "Class": "active", "Label": "sugar", "Gone": "anything"},
+		{"MW": json.Number("18"), "Class": "unknown", "Label": 3, "Gone": nil},
+		{"MW": "heavy", "Label": []string{"a"}, "Gone": 1, "ID": "row-3"},
+	}
+
+	err := Validate(independentFeatures, features, values)
+	invalid, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("error = %v, want a ValidationError", err)
+	}
+
+	want := []RowError{
+		{Row: 1, Feature: "Class", Message: `value "unknown" is not one of [active inactive]`},
+		{Row: 1, Feature: "Gone", Message: "value is null"},
+		{Row: 2, Feature: "Class", Message: "missing value"},
+		{Row: 2, Feature: "ID", Message: "not an independent feature of the model"},
+		{Row: 2, Feature: "Label", Message: "expected a nominal value, got []string"},
+		{Row: 2, Feature: "MW", Message: "expected a numeric value, got string"},
+	}
+	if !reflect.DeepEqual(invalid.Errors, want) {
+		t.Errorf("errors =\n%v\nwant\n%v", invalid.Errors, want)
+	}
+
+	if err = Validate(independentFeatures, features, values[:1]); err != nil {
+		t.Errorf("valid row: %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+package doa
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+// singularTolerance is the relative size below which a pivot or eigenvalue is treated as zero.
+const singularTolerance = 1e-10
+
+// Build computes a DOA from a training dataset without asking the server.
+// features lists the URIs of the independent features and sets the order of the DOA matrix.
+// The matrix is (XᵀX)⁻¹, or its pseudo-inverse when XᵀX is singular, and AValue is the
+// warning leverage 3p/n.
+func Build(data models.Dataset, features []string) (retDoa models.Doa, err error) {
+	x, err := designMatrix(data, features)
+	if err != nil {
+		return retDoa, err
+	}
+
+	n, p := len(x), len(features)
+	xtx := make([][]float64, p)
+	for j := range xtx {
+		xtx[j] = make([]float64, p)
+		for k := range xtx[j] {
+			for i := 0; i < n; i++ {
+				xtx[j][k] += x[i][j] * x[i][k]
+			}
+		}
+	}
+
+	inv, ok := invert(xtx)
+	if !ok {
+		inv = pseudoInverse(xtx)
+	}
+
+	retDoa.DoaMatrix = make([][]float32, p)
+	for j := range inv {
+		retDoa.DoaMatrix[j] = make([]float32, p)
+		for k := range inv[j] {
+			retDoa.DoaMatrix[j][k] = float32(inv[j][k])
+		}
+	}
+	retDoa.AValue = float32(3 * float64(p) / float64(n))
+	return retDoa, nil
+}
+
+// BuildForModel is like Build, using the model's independent features.
+func BuildForModel(currentModel models.Model, data models.Dataset) (retDoa models.Doa, err error) {
+	retDoa, err = Build(data, currentModel.IndependentFeatures)
+	if err != nil {
+		return retDoa, err
+	}
+	if currentModel.ID != "" {
+		retDoa.ModelID = currentModel.ID
+	} else {
+		retDoa.ModelID = currentModel.SlashID
+	}
+	return retDoa, nil
+}
+
+// designMatrix extracts the rows of the dataset as numeric vectors ordered by features.
+func designMatrix(data models.Dataset, features []string) ([][]float64, error) {
+	if len(features) == 0 {
+		return nil, errors.New("no independent features given")
+	}
+	if len(data.DataEntry) == 0 {
+		return nil, errors.New("dataset has no data entries")
+	}
+
+	keys := make([]string, len(features))
+	for j, uri := range features {
+		for _, info := range data.Features {
+			if info.URI == uri || lastSegment(info.URI) == lastSegment(uri) {
+				keys[j] = info.Key
+				break
+			}
+		}
+		if keys[j] == "" {
+			return nil, fmt.Errorf("dataset has no feature %s", uri)
+		}
+	}
+
+	x := make([][]float64, len(data.DataEntry))
+	for i, entry := range data.DataEntry {
+		x[i] = make([]float64, len(features))
+		for j, key := range keys {
+			f, err := models.ToFloat(entry.Values[key])
+			if err != nil {
+				return nil, fmt.Errorf("row %d: feature %s: %s", i, features[j], err.Error())
+			}
+			x[i][j] = f
+		}
+	}
+	return x, nil
+}
+
+// lastSegment returns the last path segment of a URI, i.e. the ID of the entity it points to.
+func lastSegment(uri string) string {
+	currList := strings.Split(strings.TrimRight(uri, "/"), "/")
+	return currList[len(currList)-1]
+}
+
+// invert inverts a square matrix by Gauss-Jordan elimination with partial pivoting.
+// It reports false if the matrix is singular.
+func invert(a [][]float64) ([][]float64, bool) {
+	p := len(a)
+	m := make([][]float64, p)
+	var scale float64
+	for j := range a {
+		m[j] = make([]float64, 2*p)
+		copy(m[j], a[j])
+		m[j][p+j] = 1
+		for _, v := range a[j] {
+			scale = math.Max(scale, math.Abs(v))
+		}
+	}
+	if scale == 0 {
+		return nil, false
+	}
+
+	for col := 0; col < p; col++ {
+		pivot := col
+		for row := col + 1; row < p; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) <= singularTolerance*scale {
+			return nil, false
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		div := m[col][col]
+		for k := range m[col] {
+			m[col][k] /= div
+		}
+		for row := 0; row < p; row++ {
+			if row == col || m[row][col] == 0 {
+				continue
+			}
+			factor := m[row][col]
+			for k := range m[row] {
+				m[row][k] -= factor * m[col][k]
+			}
+		}
+	}
+
+	inv := make([][]float64, p)
+	for j := range m {
+		inv[j] = m[j][p:]
+	}
+	return inv, true
+}
+
+// pseudoInverse computes the Moore-Penrose pseudo-inverse of a symmetric matrix
+// from its eigendecomposition, found with the cyclic Jacobi method.
+func pseudoInverse(a [][]float64) [][]float64 {
+	p := len(a)
+	m := make([][]float64, p)
+	v := make([][]float64, p)
+	for j := range a {
+		m[j] = append([]float64(nil), a[j]...)
+		v[j] = make([]float64, p)
+		v[j][j] = 1
+	}
+
+	for sweep := 0; sweep < 100; sweep++ {
+		var off float64
+		for j := 0; j < p; j++ {
+			for k := j + 1; k < p; k++ {
+				off += m[j][k] * m[j][k]
+			}
+		}
+		if off < 1e-30 {
+			break
+		}
+
+		for j := 0; j < p; j++ {
+			for k := j + 1; k < p; k++ {
+				if m[j][k] == 0 {
+					continue
+				}
+				theta := (m[k][k] - m[j][j]) / (2 * m[j][k])
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for i := 0; i < p; i++ {
+					mij, mik := m[i][j], m[i][k]
+					m[i][j] = c*mij - s*mik
+					m[i][k] = s*mij + c*mik
+				}
+				for i := 0; i < p; i++ {
+					mji, mki := m[j][i], m[k][i]
+					m[j][i] = c*mji - s*mki
+					m[k][i] = s*mji + c*mki
+				}
+				for i := 0; i < p; i++ {
+					vij, vik := v[i][j], v[i][k]
+					v[i][j] = c*vij - s*vik
+					v[i][k] = s*vij + c*vik
+				}
+			}
+		}
+	}
+
+	var largest float64
+	for j := 0; j < p; j++ {
+		largest = math.Max(largest, math.Abs(m[j][j]))
+	}
+
+	pinv := make([][]float64, p)
+	for i := range pinv {
+		pinv[i] = make([]float64, p)
+	}
+	for j := 0; j < p; j++ {
+		if math.Abs(m[j][j]) <= singularTolerance*largest || m[j][j] == 0 {
+			continue
+		}
+		inv := 1 / m[j][j]
+		for i := 0; i < p; i++ {
+			for k := 0; k < p; k++ {
+				pinv[i][k] += v[i][j] * inv * v[k][j]
+			}
+		}
+	}
+	return pinv
+}
@@ -0,0 +1,193 @@
+package doa
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+const tolerance = 1e-9
+
+// assertMatrix fails unless got equals want within tolerance.
+func assertMatrix(t *testing.T, got [][]float64, want [][]float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(got), len(want))
+	}
+	for j := range want {
+		for k := range want[j] {
+			if math.Abs(got[j][k]-want[j][k]) > tolerance {
+				t.Fatalf("[%d][%d] = %g, want %g\ngot:  %v\nwant: %v", j, k, got[j][k], want[j][k], got, want)
+			}
+		}
+	}
+}
+
+// multiply returns the product of two square matrices.
+func multiply(a [][]float64, b [][]float64) [][]float64 {
+	product := make([][]float64, len(a))
+	for i := range a {
+		product[i] = make([]float64, len(b[0]))
+		for j := range b[0] {
+			for k := range b {
+				product[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return product
+}
+
+func TestInvert(t *testing.T) {
+	a := [][]float64{{4, 7}, {2, 6}}
+	inv, ok := invert(a)
+	if !ok {
+		t.Fatal("invert reported a singular matrix")
+	}
+	assertMatrix(t, inv, [][]float64{{0.6, -0.7}, {-0.2, 0.4}})
+
+	// A zero on the diagonal needs a row swap.
+	a = [][]float64{{0, 2, 1}, {1, 1, 0}, {3, 0, 1}}
+	if inv, ok = invert(a); !ok {
+		t.Fatal("invert reported a singular matrix")
+	}
+	assertMatrix(t, multiply(a, inv), [][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}})
+}
+
+func TestInvertSingular(t *testing.T) {
+	for _, a := range [][][]float64{
+		{{1, 2}, {2, 4}},
+		{{0, 0}, {0, 0}},
+		{{1, 1, 2}, {1, 1, 2}, {0, 3, 1}},
+	} {
+		if inv, ok := invert(a); ok {
+			t.Errorf("invert(%v) = %v, want singular", a, inv)
+		}
+	}
+}
+
+func TestPseudoInverse(t *testing.T) {
+	// For an invertible matrix the pseudo-inverse is the inverse.
+	assertMatrix(t, pseudoInverse([][]float64{{2, 1}, {1, 3}}), [][]float64{{0.6, -0.2}, {-0.2, 0.4}})
+
+	singular := [][]float64{{1, 1}, {1, 1}}
+	pinv := pseudoInverse(singular)
+	assertMatrix(t, pinv, [][]float64{{0.25, 0.25}, {0.25, 0.25}})
+	// Moore-Penrose conditions: A P A = A and P A P = P.
+	assertMatrix(t, multiply(multiply(singular, pinv), singular), singular)
+	assertMatrix(t, multiply(multiply(pinv, singular), pinv), pinv)
+
+	assertMatrix(t, pseudoInverse([][]float64{{0, 0}, {0, 0}}), [][]float64{{0, 0}, {0, 0}})
+}
+
+// trainingSet builds a dataset of rows of two features, keyed "0" and "1".
+func trainingSet(rows ...[2]interface{}) models.Dataset {
+	data := models.Dataset{Features: []models.FeatureInfo{
+		{Key: "0", Name: "MW", URI: "https://api.jaqpot.org/jaqpot/services/feature/mw"},
+		{Key: "1", Name: "TPSA", URI: "https://api.jaqpot.org/jaqpot/services/feature/tpsa"},
+	}}
+	for _, row := range rows {
+		data.DataEntry = append(data.DataEntry, models.DataEntry{Values: map[string]interface{}{"0": row[0], "1": row[1]}})
+	}
+	return data
+}
+
+func TestBuild(t *testing.T) {
+	data := trainingSet([2]interface{}{1.0, 0.0}, [2]interface{}{0, 1}, [2]interface{}{int64(1), float32(1)}, [2]interface{}{2.0, 1.0})
+
+	// Features may be given by URI or by ID.
+	built, err := Build(data, []string{"https://api.jaqpot.org/jaqpot/services/feature/mw", "tpsa"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// XᵀX = [[6 3] [3 3]], whose inverse is [[1/3 -1/3] [-1/3 2/3]].
+	got := make([][]float64, len(built.DoaMatrix))
+	for j, row := range built.DoaMatrix {
+		for _, v := range row {
+			got[j] = append(got[j], float64(v))
+		}
+	}
+	want := [][]float64{{1.0 / 3, -1.0 / 3}, {-1.0 / 3, 2.0 / 3}}
+	for j := range want {
+		for k := range want[j] {
+			if math.Abs(got[j][k]-want[j][k]) > 1e-6 {
+				t.Fatalf("DoaMatrix = %v, want %v", got, want)
+			}
+		}
+	}
+	if built.AValue != 1.5 {
+		t.Errorf("AValue = %v, want 3p/n = 1.5", built.AValue)
+	}
+
+	assessments, err := Assess(built, []string{"MW", "TPSA"}, []map[string]interface{}{
+		{"MW": 1.0, "TPSA": 0.0},
+		{"MW": 10.0, "TPSA": 0.0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !assessments[0].InDomain || math.Abs(assessments[0].Leverage-1.0/3) > 1e-6 {
+		t.Errorf("training row: %+v, want leverage 1/3 in domain", assessments[0])
+	}
+	if assessments[1].InDomain {
+		t.Errorf("distant row: %+v, want outside the domain", assessments[1])
+	}
+}
+
+func TestBuildSingular(t *testing.T) {
+	// Collinear features make XᵀX singular; the pseudo-inverse still gives finite leverages.
+	data := trainingSet([2]interface{}{1.0, 2.0}, [2]interface{}{2.0, 4.0}, [2]interface{}{3.0, 6.0})
+	built, err := Build(data, []string{"mw", "tpsa"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for row, x := range [][]float64{{1, 2}, {2, 4}, {3, 6}} {
+		h, err := Leverage(built, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// With one independent direction the leverages are x²/Σx² = 1/14, 4/14 and 9/14.
+		want := float64((row+1)*(row+1)) / 14
+		if math.IsNaN(h) || math.Abs(h-want) > 1e-5 {
+			t.Errorf("row %d: leverage = %v, want %v", row, h, want)
+		}
+	}
+}
+
+func TestBuildForModel(t *testing.T) {
+	data := trainingSet([2]interface{}{1.0, 0.0}, [2]interface{}{0.0, 1.0})
+	built, err := BuildForModel(models.Model{SlashID: "BQrMGTaFSzpMtVYFz0WS", IndependentFeatures: []string{"mw", "tpsa"}}, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if built.ModelID != "BQrMGTaFSzpMtVYFz0WS" {
+		t.Errorf("ModelID = %q, want the model's _id", built.ModelID)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     models.Dataset
+		features []string
+		want     string
+	}{
+		{"no features", trainingSet([2]interface{}{1.0, 0.0}), nil, "no independent features"},
+		{"no rows", trainingSet(), []string{"mw"}, "no data entries"},
+		{"unknown feature", trainingSet([2]interface{}{1.0, 0.0}), []string{"logp"}, "no feature logp"},
+		{"not numeric", trainingSet([2]interface{}{"heavy", 0.0}), []string{"mw"}, "row 0: feature mw"},
+		{"missing value", trainingSet([2]interface{}{nil, 0.0}), []string{"mw"}, "missing value"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Build(test.data, test.features)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("Build error = %v, want one containing %q", err, test.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package doa
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
+	"github.com/euclia/gojaqpot/model"
 	"github.com/euclia/gojaqpot/models"
 )
 
@@ -20,17 +24,16 @@ func GetDOA(modelID string, AuthToken string, BaseURL string, HTTPClient *http.C
 	req, err := http.NewRequest("GET", endpoint, nil)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+AuthToken)
-	// req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept", "application/json")
 
 	q := req.URL.Query()
 	q.Add("hasSources", modelID)
 
-	// req.URL.RawQuery = q.Encode()
+	req.URL.RawQuery = q.Encode()
 	resp, err := HTTPClient.Do(req)
 	var returnDoa models.Doa
 
 	if err != nil {
-		fmt.Printf(err.Error())
 		return returnDoa, err
 	}
 
@@ -38,7 +41,111 @@ func GetDOA(modelID string, AuthToken string, BaseURL string, HTTPClient *http.C
 		var errorReport models.ErrorReport
 		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
 		defer resp.Body.Close()
-		err = errors.New(errorReport.Message)
+		err = models.NewAPIError(resp.StatusCode, errorReport)
+		return returnDoa, err
+	}
+	defer resp.Body.Close()
+
+	// The lookup may answer with the matching DOAs or with the DOA itself.
+	var raw json.RawMessage
+	if err = json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return returnDoa, err
+	}
+
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		var doas []models.Doa
+		if err = json.Unmarshal(trimmed, &doas); err != nil {
+			return returnDoa, err
+		}
+		if len(doas) == 0 {
+			return returnDoa, models.NewAPIError(http.StatusNotFound, models.ErrorReport{Message: "no DOA found for model " + modelID})
+		}
+		return doas[0], nil
+	}
+
+	err = json.Unmarshal(raw, &returnDoa)
+	return returnDoa, err
+}
+
+// GetDOAs is a method to get a list of DOAs.
+func GetDOAs(min int, max int, AuthToken string, BaseURL string, HTTPClient *http.Client) (doas models.Doas, err error) {
+	var endpoint = BaseURL + doaPath
+	req, err := http.NewRequest("GET", endpoint, nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+AuthToken)
+
+	q := req.URL.Query()
+	q.Add("min", strconv.Itoa(min))
+	q.Add("max", strconv.Itoa(max))
+
+	req.URL.RawQuery = q.Encode()
+	resp, err := HTTPClient.Do(req)
+	var returnDoas models.Doas
+
+	if err != nil {
+		return returnDoas, err
+	}
+
+	if resp.StatusCode != 200 {
+		var errorReport models.ErrorReport
+		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
+		defer resp.Body.Close()
+		err = models.NewAPIError(resp.StatusCode, errorReport)
+		return returnDoas, err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&returnDoas.Doas)
+
+	returnDoas.Total, _ = strconv.Atoi(resp.Header.Get("Total"))
+
+	return returnDoas, err
+}
+
+// CreateDOA is a method to create a model's DOA from the model's training dataset.
+func CreateDOA(modelID string, AuthToken string, BaseURL string, HTTPClient *http.Client) (retDoa models.Doa, err error) {
+	return submitDOA("POST", modelID, AuthToken, BaseURL, HTTPClient)
+}
+
+// RecomputeDOA is a method to recompute a model's DOA, e.g. after the model has been retrained.
+func RecomputeDOA(modelID string, AuthToken string, BaseURL string, HTTPClient *http.Client) (retDoa models.Doa, err error) {
+	return submitDOA("PUT", modelID, AuthToken, BaseURL, HTTPClient)
+}
+
+// submitDOA asks the server to compute a model's DOA from the model's current training dataset.
+func submitDOA(method string, modelID string, AuthToken string, BaseURL string, HTTPClient *http.Client) (retDoa models.Doa, err error) {
+	var endpoint = BaseURL + doaPath
+	var returnDoa models.Doa
+
+	currentModel, err := model.GetModel(modelID, AuthToken, BaseURL, HTTPClient)
+	if err != nil {
+		return returnDoa, err
+	}
+	if currentModel.DatasetURI == "" {
+		return returnDoa, errors.New("model " + modelID + " has no training dataset")
+	}
+
+	body := url.Values{}
+	body.Set("modelId", modelID)
+	body.Set("dataset_uri", currentModel.DatasetURI)
+
+	req, err := http.NewRequest(method, endpoint, strings.NewReader(body.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+AuthToken)
+
+	resp, err := HTTPClient.Do(req)
+
+	if err != nil {
+		return returnDoa, err
+	}
+
+	if resp.StatusCode >= 300 {
+		var errorReport models.ErrorReport
+		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
+		defer resp.Body.Close()
+		err = models.NewAPIError(resp.StatusCode, errorReport)
 		return returnDoa, err
 	}
 	defer resp.Body.Close()
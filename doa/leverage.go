@@ -0,0 +1,56 @@
+package doa
+
+import (
+	"fmt"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+// Leverage computes the leverage h = xᵀ M x of a row x from the DOA matrix M.
+func Leverage(modelDoa models.Doa, x []float64) (h float64, err error) {
+	if len(modelDoa.DoaMatrix) != len(x) {
+		return 0, fmt.Errorf("DOA matrix has %d rows, row has %d values", len(modelDoa.DoaMatrix), len(x))
+	}
+
+	for j := range x {
+		if len(modelDoa.DoaMatrix[j]) != len(x) {
+			return 0, fmt.Errorf("DOA matrix row %d has %d columns, expected %d", j, len(modelDoa.DoaMatrix[j]), len(x))
+		}
+		for k := range x {
+			h += x[j] * float64(modelDoa.DoaMatrix[j][k]) * x[k]
+		}
+	}
+	return h, nil
+}
+
+// Assess computes the leverage of every row and compares it to the DOA's AValue.
+// features lists the feature names of the rows in the order of the DOA matrix.
+func Assess(modelDoa models.Doa, features []string, values []map[string]interface{}) (assessments []models.DomainAssessment, err error) {
+	assessments = make([]models.DomainAssessment, len(values))
+	x := make([]float64, len(features))
+
+	for row, item := range values {
+		for j, name := range features {
+			x[j], err = models.ToFloat(item[name])
+			if err != nil {
+				return nil, fmt.Errorf("row %d: feature %q: %s", row, name, err.Error())
+			}
+		}
+
+		h, err := Leverage(modelDoa, x)
+		if err != nil {
+			return nil, err
+		}
+		assessments[row] = models.DomainAssessment{Leverage: h, InDomain: h <= float64(modelDoa.AValue)}
+	}
+	return assessments, nil
+}
+
+// AssessModel is like Assess, taking the feature order from the model's independent features.
+func AssessModel(currentModel models.Model, modelDoa models.Doa, values []map[string]interface{}) (assessments []models.DomainAssessment, err error) {
+	features, err := currentModel.IndependentFeatureNames()
+	if err != nil {
+		return nil, err
+	}
+	return Assess(modelDoa, features, values)
+}
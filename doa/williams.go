@@ -0,0 +1,214 @@
+package doa
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+// ResidualLimit is the absolute standardised residual beyond which a point is a response outlier.
+const ResidualLimit = 3.0
+
+// Classes of a point on a Williams plot.
+const (
+	ClassInDomain        = "IN_DOMAIN"
+	ClassResponseOutlier = "RESPONSE_OUTLIER"
+	ClassHighLeverage    = "HIGH_LEVERAGE"
+	ClassBothOutlier     = "HIGH_LEVERAGE_RESPONSE_OUTLIER"
+)
+
+// Williams computes the data of a Williams plot (standardised residuals against leverage) for a prediction.
+// observed holds the experimental value of every predicted row. Leverages are taken from prediction.Domain
+// when present, otherwise computed from the DOA with features giving the order of the DOA matrix.
+// The threshold h* is the DOA's AValue.
+func Williams(prediction models.Prediction, observed []float64, modelDoa models.Doa, features []string) (plot models.WilliamsPlot, err error) {
+	n := len(prediction.Predictions)
+	if n == 0 {
+		return plot, errors.New("prediction has no predicted values")
+	}
+	if len(observed) != n {
+		return plot, fmt.Errorf("got %d observed values for %d predictions", len(observed), n)
+	}
+
+	assessments := prediction.Domain
+	if len(assessments) != n {
+		if len(prediction.Data) != n {
+			return plot, fmt.Errorf("prediction has %d data rows for %d predictions", len(prediction.Data), n)
+		}
+		assessments, err = Assess(modelDoa, features, prediction.Data)
+		if err != nil {
+			return plot, err
+		}
+	}
+
+	plot.Threshold = float64(modelDoa.AValue)
+	plot.ResidualLimit = ResidualLimit
+	plot.Points = make([]models.WilliamsPoint, n)
+
+	var sse float64
+	for i, pred := range prediction.Predictions {
+		keys := make([]string, 0, len(pred))
+		for key := range pred {
+			keys = append(keys, key)
+		}
+		if len(keys) == 0 {
+			return plot, fmt.Errorf("row %d has no predicted value", i)
+		}
+		sort.Strings(keys)
+
+		predicted, err := models.ToFloat(pred[keys[0]])
+		if err != nil {
+			return plot, fmt.Errorf("row %d: predicted value: %s", i, err.Error())
+		}
+
+		residual := observed[i] - predicted
+		sse += residual * residual
+		plot.Points[i] = models.WilliamsPoint{Row: i, Observed: observed[i], Predicted: predicted, Leverage: assessments[i].Leverage, StandardisedResidual: residual}
+	}
+
+	// The residual standard deviation uses n - p degrees of freedom when there are enough rows.
+	dof := n - len(modelDoa.DoaMatrix)
+	if dof <= 0 {
+		dof = n
+	}
+	s := math.Sqrt(sse / float64(dof))
+
+	for i := range plot.Points {
+		point := &plot.Points[i]
+		if s > 0 {
+			point.StandardisedResidual /= s
+		} else {
+			point.StandardisedResidual = 0
+		}
+
+		highLeverage := point.Leverage > plot.Threshold
+		responseOutlier := math.Abs(point.StandardisedResidual) > plot.ResidualLimit
+		switch {
+		case highLeverage && responseOutlier:
+			point.Class = ClassBothOutlier
+		case highLeverage:
+			point.Class = ClassHighLeverage
+		case responseOutlier:
+			point.Class = ClassResponseOutlier
+		default:
+			point.Class = ClassInDomain
+		}
+	}
+	return plot, nil
+}
+
+// WriteWilliamsCSV writes the points of a Williams plot as CSV, with a header row.
+func WriteWilliamsCSV(w io.Writer, plot models.WilliamsPlot) (err error) {
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"row", "observed", "predicted", "leverage", "standardised_residual", "class", "threshold"})
+
+	threshold := strconv.FormatFloat(plot.Threshold, 'g', -1, 64)
+	for _, point := range plot.Points {
+		_ = writer.Write([]string{
+			strconv.Itoa(point.Row),
+			strconv.FormatFloat(point.Observed, 'g', -1, 64),
+			strconv.FormatFloat(point.Predicted, 'g', -1, 64),
+			strconv.FormatFloat(point.Leverage, 'g', -1, 64),
+			strconv.FormatFloat(point.StandardisedResidual, 'g', -1, 64),
+			point.Class,
+			threshold,
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Dimensions of the SVG chart, in pixels.
+const (
+	svgWidth  = 640
+	svgHeight = 480
+	svgMargin = 60
+)
+
+// classColours maps the class of a point to its colour on the SVG chart.
+var classColours = map[string]string{
+	ClassInDomain:        "#1f77b4",
+	ClassResponseOutlier: "#ff7f0e",
+	ClassHighLeverage:    "#2ca02c",
+	ClassBothOutlier:     "#d62728",
+}
+
+// WriteWilliamsSVG writes a Williams plot as a self-contained SVG chart.
+func WriteWilliamsSVG(w io.Writer, plot models.WilliamsPlot) (err error) {
+	limit := plot.ResidualLimit
+	if limit == 0 {
+		limit = ResidualLimit
+	}
+
+	maxH := plot.Threshold * 1.2
+	maxR := limit + 1
+	for _, point := range plot.Points {
+		maxH = math.Max(maxH, point.Leverage*1.05)
+		maxR = math.Max(maxR, math.Abs(point.StandardisedResidual)*1.05)
+	}
+	if maxH <= 0 {
+		maxH = 1
+	}
+
+	plotW := float64(svgWidth - 2*svgMargin)
+	plotH := float64(svgHeight - 2*svgMargin)
+	px := func(h float64) float64 { return svgMargin + h/maxH*plotW }
+	py := func(r float64) float64 { return svgMargin + (maxR-r)/(2*maxR)*plotH }
+
+	ew := &errWriter{w: w}
+	ew.printf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n", svgWidth, svgHeight, svgWidth, svgHeight)
+	ew.printf(`<rect width="%d" height="%d" fill="white"/>`+"\n", svgWidth, svgHeight)
+	ew.printf(`<text x="%d" y="%d" text-anchor="middle" font-size="16">Williams plot</text>`+"\n", svgWidth/2, svgMargin/2)
+
+	// Axes and tick labels.
+	ew.printf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", svgMargin, svgHeight-svgMargin, svgWidth-svgMargin, svgHeight-svgMargin)
+	ew.printf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", svgMargin, svgMargin, svgMargin, svgHeight-svgMargin)
+	for tick := 0; tick <= 5; tick++ {
+		h := maxH * float64(tick) / 5
+		r := -maxR + 2*maxR*float64(tick)/5
+		ew.printf(`<text x="%.1f" y="%d" text-anchor="middle">%.3g</text>`+"\n", px(h), svgHeight-svgMargin+16, h)
+		ew.printf(`<text x="%d" y="%.1f" text-anchor="end" dominant-baseline="middle">%.2g</text>`+"\n", svgMargin-6, py(r), r)
+	}
+	ew.printf(`<text x="%d" y="%d" text-anchor="middle">Leverage (h)</text>`+"\n", svgWidth/2, svgHeight-svgMargin/3)
+	ew.printf(`<text x="%d" y="%d" text-anchor="middle" transform="rotate(-90 %d %d)">Standardised residual</text>`+"\n", svgMargin/3, svgHeight/2, svgMargin/3, svgHeight/2)
+
+	// Limits: h* and ±residual limit.
+	ew.printf(`<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="grey" stroke-dasharray="6,4"/>`+"\n", px(plot.Threshold), svgMargin, px(plot.Threshold), svgHeight-svgMargin)
+	ew.printf(`<text x="%.1f" y="%d" fill="grey">h* = %.3g</text>`+"\n", px(plot.Threshold)+4, svgMargin+12, plot.Threshold)
+	for _, r := range []float64{limit, -limit} {
+		ew.printf(`<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="grey" stroke-dasharray="6,4"/>`+"\n", svgMargin, py(r), svgWidth-svgMargin, py(r))
+	}
+
+	for _, point := range plot.Points {
+		colour, ok := classColours[point.Class]
+		if !ok {
+			colour = "black"
+		}
+		ew.printf(`<circle cx="%.1f" cy="%.1f" r="4" fill="%s"><title>row %d: h=%.4g, r=%.4g (%s)</title></circle>`+"\n",
+			px(point.Leverage), py(point.StandardisedResidual), colour, point.Row, point.Leverage, point.StandardisedResidual, html.EscapeString(point.Class))
+	}
+
+	ew.printf("</svg>\n")
+	return ew.err
+}
+
+// errWriter remembers the first write error, so a sequence of writes can be checked once.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...interface{}) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = fmt.Fprintf(ew.w, format, args...)
+}
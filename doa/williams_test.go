@@ -0,0 +1,176 @@
+package doa
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+// williamsPrediction builds a prediction of 12 rows predicting 1.0, with row 0 of high leverage
+// and row 11 observed 10 above its prediction.
+func williamsPrediction() (models.Prediction, []float64, models.Doa) {
+	var prediction models.Prediction
+	observed := make([]float64, 12)
+	for i := range observed {
+		prediction.Predictions = append(prediction.Predictions, map[string]interface{}{"LogP": 1.0})
+		prediction.Domain = append(prediction.Domain, models.DomainAssessment{Leverage: 0.1, InDomain: true})
+		observed[i] = 1.0
+	}
+	prediction.Domain[0] = models.DomainAssessment{Leverage: 0.9}
+	prediction.Domain[11] = models.DomainAssessment{Leverage: 0.9}
+	observed[11] = 11.0
+
+	return prediction, observed, models.Doa{DoaMatrix: [][]float32{{1}}, AValue: 0.5}
+}
+
+func TestWilliams(t *testing.T) {
+	prediction, observed, modelDoa := williamsPrediction()
+	plot, err := Williams(prediction, observed, modelDoa, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plot.Threshold != 0.5 || plot.ResidualLimit != ResidualLimit {
+		t.Errorf("limits = %v and %v, want the DOA's AValue and ResidualLimit", plot.Threshold, plot.ResidualLimit)
+	}
+	if len(plot.Points) != 12 {
+		t.Fatalf("got %d points, want 12", len(plot.Points))
+	}
+
+	// The residual standard deviation is sqrt(100 / (12 - 1)), so row 11 is sqrt(11) deviations away.
+	if r := plot.Points[11].StandardisedResidual; math.Abs(r-math.Sqrt(11)) > 1e-9 {
+		t.Errorf("row 11 standardised residual = %v, want %v", r, math.Sqrt(11))
+	}
+
+	want := map[int]string{0: ClassHighLeverage, 5: ClassInDomain, 11: ClassBothOutlier}
+	for row, class := range want {
+		if plot.Points[row].Class != class {
+			t.Errorf("row %d class = %s, want %s", row, plot.Points[row].Class, class)
+		}
+	}
+	if p := plot.Points[11]; p.Row != 11 || p.Observed != 11 || p.Predicted != 1 || p.Leverage != 0.9 {
+		t.Errorf("row 11 = %+v", p)
+	}
+}
+
+func TestWilliamsResponseOutlier(t *testing.T) {
+	prediction, observed, modelDoa := williamsPrediction()
+	prediction.Domain[11].Leverage = 0.1
+
+	plot, err := Williams(prediction, observed, modelDoa, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plot.Points[11].Class != ClassResponseOutlier {
+		t.Errorf("row 11 class = %s, want %s", plot.Points[11].Class, ClassResponseOutlier)
+	}
+}
+
+func TestWilliamsComputesLeverages(t *testing.T) {
+	prediction := models.Prediction{
+		Data:        []map[string]interface{}{{"MW": 1.0}, {"MW": 4.0}},
+		Predictions: []map[string]interface{}{{"LogP": 1.0}, {"LogP": 2.0}},
+	}
+	modelDoa := models.Doa{DoaMatrix: [][]float32{{0.25}}, AValue: 1}
+
+	plot, err := Williams(prediction, []float64{1, 2}, modelDoa, []string{"MW"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plot.Points[0].Leverage != 0.25 || plot.Points[1].Leverage != 4 {
+		t.Errorf("leverages = %v and %v, want 0.25 and 4", plot.Points[0].Leverage, plot.Points[1].Leverage)
+	}
+	if plot.Points[0].Class != ClassInDomain || plot.Points[1].Class != ClassHighLeverage {
+		t.Errorf("classes = %s and %s", plot.Points[0].Class, plot.Points[1].Class)
+	}
+	// Perfect predictions have no residual spread.
+	if plot.Points[0].StandardisedResidual != 0 {
+		t.Errorf("standardised residual = %v, want 0", plot.Points[0].StandardisedResidual)
+	}
+}
+
+func TestWilliamsErrors(t *testing.T) {
+	prediction, observed, modelDoa := williamsPrediction()
+
+	if _, err := Williams(models.Prediction{}, nil, modelDoa, nil); err == nil {
+		t.Error("no error for a prediction without predicted values")
+	}
+	if _, err := Williams(prediction, observed[:3], modelDoa, nil); err == nil || !strings.Contains(err.Error(), "3 observed values for 12 predictions") {
+		t.Errorf("error = %v, want a count mismatch", err)
+	}
+
+	prediction.Domain = nil
+	if _, err := Williams(prediction, observed, modelDoa, []string{"MW"}); err == nil {
+		t.Error("no error for leverages without data rows")
+	}
+
+	prediction, observed, modelDoa = williamsPrediction()
+	prediction.Predictions[3] = map[string]interface{}{"LogP": "high"}
+	if _, err := Williams(prediction, observed, modelDoa, nil); err == nil || !strings.Contains(err.Error(), "row 3") {
+		t.Errorf("error = %v, want one about row 3", err)
+	}
+}
+
+func TestWriteWilliamsCSV(t *testing.T) {
+	plot := models.WilliamsPlot{
+		Threshold:     0.5,
+		ResidualLimit: ResidualLimit,
+		Points: []models.WilliamsPoint{
+			{Row: 0, Observed: 1.5, Predicted: 1.25, Leverage: 0.1, StandardisedResidual: 0.25, Class: ClassInDomain},
+			{Row: 1, Observed: 9, Predicted: 2, Leverage: 0.75, StandardisedResidual: 3.5, Class: ClassBothOutlier},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteWilliamsCSV(&buf, plot); err != nil {
+		t.Fatal(err)
+	}
+	want := "row,observed,predicted,leverage,standardised_residual,class,threshold\n" +
+		"0,1.5,1.25,0.1,0.25,IN_DOMAIN,0.5\n" +
+		"1,9,2,0.75,3.5,HIGH_LEVERAGE_RESPONSE_OUTLIER,0.5\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriteWilliamsSVG(t *testing.T) {
+	prediction, observed, modelDoa := williamsPrediction()
+	plot, err := Williams(prediction, observed, modelDoa, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plot.Points[5].Class = "<unknown>"
+
+	var buf bytes.Buffer
+	if err = WriteWilliamsSVG(&buf, plot); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	if !strings.HasPrefix(svg, "<svg ") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Errorf("not an SVG document:\n%s", svg)
+	}
+	if got := strings.Count(svg, "<circle "); got != len(plot.Points) {
+		t.Errorf("got %d points drawn, want %d", got, len(plot.Points))
+	}
+	if !strings.Contains(svg, `fill="#d62728"`) {
+		t.Error("outliers on both axes are not drawn in their colour")
+	}
+	if strings.Contains(svg, "<unknown>") || !strings.Contains(svg, "&lt;unknown&gt;") {
+		t.Error("class is not escaped")
+	}
+
+	if err = WriteWilliamsSVG(failingWriter{}, plot); err == nil || err.Error() != "disk full" {
+		t.Errorf("error = %v, want the write error", err)
+	}
+}
@@ -1,10 +1,14 @@
 package feature
 
 import (
+	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/euclia/gojaqpot/models"
 )
@@ -24,7 +28,6 @@ func GetFeature(featureID string, AuthToken string, BaseURL string, HTTPClient *
 	var returnFeat models.Feature
 
 	if err != nil {
-		fmt.Printf(err.Error())
 		return returnFeat, err
 	}
 
@@ -32,7 +35,7 @@ func GetFeature(featureID string, AuthToken string, BaseURL string, HTTPClient *
 		var errorReport models.ErrorReport
 		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
 		defer resp.Body.Close()
-		err = errors.New(errorReport.Message)
+		err = models.NewAPIError(resp.StatusCode, errorReport)
 		return returnFeat, err
 	}
 	defer resp.Body.Close()
@@ -40,3 +43,176 @@ func GetFeature(featureID string, AuthToken string, BaseURL string, HTTPClient *
 	err = json.NewDecoder(resp.Body).Decode(&returnFeat)
 	return returnFeat, err
 }
+
+// batchConcurrency is the number of features GetFeatures fetches at once.
+const batchConcurrency = 8
+
+// ListFeatures is a method to get a list of features.
+func ListFeatures(min int, max int, AuthToken string, BaseURL string, HTTPClient *http.Client) (feats models.Features, err error) {
+	return SearchFeatures("", "", min, max, AuthToken, BaseURL, HTTPClient)
+}
+
+// SearchFeatures is a method to get a list of features by title and/or ontological class (empty values are ignored).
+func SearchFeatures(title string, ontologicalClass string, min int, max int, AuthToken string, BaseURL string, HTTPClient *http.Client) (feats models.Features, err error) {
+	var endpoint = BaseURL + featurePath
+	req, err := http.NewRequest("GET", endpoint, nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+AuthToken)
+
+	q := req.URL.Query()
+	if title != "" {
+		q.Add("title", title)
+	}
+	if ontologicalClass != "" {
+		q.Add("ontologicalClass", ontologicalClass)
+	}
+	q.Add("min", strconv.Itoa(min))
+	q.Add("max", strconv.Itoa(max))
+
+	req.URL.RawQuery = q.Encode()
+	resp, err := HTTPClient.Do(req)
+	var returnFeats models.Features
+
+	if err != nil {
+		return returnFeats, err
+	}
+
+	if resp.StatusCode != 200 {
+		var errorReport models.ErrorReport
+		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
+		defer resp.Body.Close()
+		err = models.NewAPIError(resp.StatusCode, errorReport)
+		return returnFeats, err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&returnFeats.Features)
+
+	returnFeats.Total, _ = strconv.Atoi(resp.Header.Get("Total"))
+
+	return returnFeats, err
+}
+
+// CreateFeature is a method to create a feature, e.g. with its units and admissible values.
+func CreateFeature(feat models.Feature, AuthToken string, BaseURL string, HTTPClient *http.Client) (retFeat models.Feature, err error) {
+	return sendFeature("POST", BaseURL+featurePath, feat, AuthToken, HTTPClient)
+}
+
+// UpdateFeature is a method to update a feature's metadata, units and admissible values.
+func UpdateFeature(featureID string, feat models.Feature, AuthToken string, BaseURL string, HTTPClient *http.Client) (retFeat models.Feature, err error) {
+	return sendFeature("PUT", BaseURL+featurePath+featureID, feat, AuthToken, HTTPClient)
+}
+
+// sendFeature sends a feature as JSON and decodes the feature the server answers with.
+func sendFeature(method string, endpoint string, feat models.Feature, AuthToken string, HTTPClient *http.Client) (retFeat models.Feature, err error) {
+	var returnFeat models.Feature
+	body, err := json.Marshal(feat)
+	if err != nil {
+		return returnFeat, err
+	}
+
+	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+AuthToken)
+
+	resp, err := HTTPClient.Do(req)
+
+	if err != nil {
+		return returnFeat, err
+	}
+
+	if resp.StatusCode >= 300 {
+		var errorReport models.ErrorReport
+		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
+		defer resp.Body.Close()
+		err = models.NewAPIError(resp.StatusCode, errorReport)
+		return returnFeat, err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&returnFeat)
+	if err == io.EOF {
+		// Some deployments answer with an empty body and the new feature's Location only.
+		returnFeat = feat
+		if location := resp.Header.Get("Location"); location != "" {
+			currList := strings.Split(location, "/")
+			returnFeat.ID = currList[len(currList)-1]
+		}
+		err = nil
+	}
+	return returnFeat, err
+}
+
+// GetFeatures is a method to get several features by ID (or URI) concurrently.
+// Duplicate IDs are fetched once; the result is in the order of featureIDs.
+func GetFeatures(featureIDs []string, AuthToken string, BaseURL string, HTTPClient *http.Client) (feats []models.Feature, err error) {
+	fetchedByID := fetchAll(featureIDs, AuthToken, BaseURL, HTTPClient)
+
+	feats = make([]models.Feature, len(featureIDs))
+	for i, id := range featureIDs {
+		result := fetchedByID[featureID(id)]
+		if result.err != nil {
+			return nil, fmt.Errorf("feature %s: %w", id, result.err)
+		}
+		feats[i] = result.feat
+	}
+	return feats, nil
+}
+
+// GetFeatureMap is a method to get several features by ID (or URI) concurrently, keyed by the ID or URI given.
+// Features that cannot be fetched are left out; err reports one of them, if any.
+func GetFeatureMap(featureIDs []string, AuthToken string, BaseURL string, HTTPClient *http.Client) (feats map[string]models.Feature, err error) {
+	fetchedByID := fetchAll(featureIDs, AuthToken, BaseURL, HTTPClient)
+
+	feats = make(map[string]models.Feature, len(featureIDs))
+	for _, id := range featureIDs {
+		result := fetchedByID[featureID(id)]
+		if result.err != nil {
+			err = fmt.Errorf("feature %s: %w", id, result.err)
+			continue
+		}
+		feats[id] = result.feat
+	}
+	return feats, err
+}
+
+// fetched is the outcome of fetching one feature.
+type fetched struct {
+	feat models.Feature
+	err  error
+}
+
+// fetchAll fetches every feature once, batchConcurrency at a time, keyed by feature ID.
+func fetchAll(featureIDs []string, AuthToken string, BaseURL string, HTTPClient *http.Client) map[string]*fetched {
+	var ids []string
+	unique := make(map[string]*fetched)
+	for _, id := range featureIDs {
+		if _, ok := unique[featureID(id)]; !ok {
+			unique[featureID(id)] = &fetched{}
+			ids = append(ids, featureID(id))
+		}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchConcurrency)
+	for _, id := range ids {
+		wg.Add(1)
+		go func(result *fetched, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result.feat, result.err = GetFeature(id, AuthToken, BaseURL, HTTPClient)
+		}(unique[id], id)
+	}
+	wg.Wait()
+	return unique
+}
+
+// featureID returns the ID of a feature given its ID or URI.
+func featureID(idOrURI string) string {
+	currList := strings.Split(strings.TrimRight(idOrURI, "/"), "/")
+	return currList[len(currList)-1]
+}
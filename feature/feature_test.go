@@ -0,0 +1,46 @@
+package feature
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+// featureServer answers feature requests with a feature titled after its ID, or 404 for IDs starting with "gone".
+func featureServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(id, "gone") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"no feature ` + id + `"}`))
+			return
+		}
+		w.Write([]byte(`{"_id":"` + id + `","meta":{"titles":["` + id + `"]}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetFeatureMap(t *testing.T) {
+	server := featureServer(t)
+	uris := []string{"https://api.jaqpot.org/jaqpot/services/feature/f1", "f2", "gone1"}
+
+	feats, err := GetFeatureMap(uris, "token", server.URL+"/", server.Client())
+	var apiErr *models.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("error = %v, want the 404 of the missing feature", err)
+	}
+	if len(feats) != 2 || feats[uris[0]].Meta.Titles[0] != "f1" || feats["f2"].Meta.Titles[0] != "f2" {
+		t.Errorf("features = %+v, want f1 and f2 keyed as given", feats)
+	}
+
+	_, err = GetFeatures(uris, "token", server.URL+"/", server.Client())
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("GetFeatures error = %v, want the 404 of the missing feature", err)
+	}
+}
@@ -0,0 +1,255 @@
+// Package gateway serves Jaqpot predictions over a simplified REST API.
+//
+// A Handler answers POST /predict/{modelId} with a JSON array of rows, keyed by the model's
+// independent feature names, and responds synchronously with the predictions, hiding the
+// dataset upload and task polling Jaqpot requires.
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	gojaqpot "github.com/euclia/gojaqpot"
+	"github.com/euclia/gojaqpot/dataset"
+	"github.com/euclia/gojaqpot/models"
+)
+
+const (
+	// PredictPath is the path prefix of prediction requests, followed by the model ID.
+	PredictPath = "/predict/"
+
+	// DefaultMaxBodyBytes is the default limit of a request body.
+	DefaultMaxBodyBytes = 1 << 20
+
+	// DefaultMaxRows is the default limit of rows in one request.
+	DefaultMaxRows = 1000
+)
+
+// Options configures a Handler.
+type Options struct {
+	// ServiceToken is the Jaqpot token used for callers that send no bearer token.
+	// Without it such callers are rejected.
+	ServiceToken string
+	// IgnoreCallerToken makes every request use ServiceToken, instead of forwarding the caller's bearer token.
+	IgnoreCallerToken bool
+	// MaxBodyBytes limits the size of a request body (defaults to DefaultMaxBodyBytes).
+	MaxBodyBytes int64
+	// MaxRows limits the number of rows of a request (defaults to DefaultMaxRows).
+	MaxRows int
+}
+
+// Response is the body of a successful prediction.
+type Response struct {
+	ModelID     string                    `json:"modelId"`
+	DatasetID   string                    `json:"datasetId,omitempty"`
+	Predictions []map[string]interface{}  `json:"predictions"`
+	Domain      []models.DomainAssessment `json:"domain,omitempty"`
+}
+
+// ErrorResponse is the body of a failed request, shaped after Jaqpot's ErrorReport.
+type ErrorResponse struct {
+	Code       string         `json:"code"`
+	Message    string         `json:"message"`
+	Details    string         `json:"details,omitempty"`
+	HTTPStatus int            `json:"httpStatus"`
+	Errors     []InvalidValue `json:"errors,omitempty"`
+}
+
+// InvalidValue is an input value rejected by validation.
+type InvalidValue struct {
+	Row     int    `json:"row"`
+	Feature string `json:"feature"`
+	Message string `json:"message"`
+}
+
+// Handler serves predictions of Jaqpot models.
+type Handler struct {
+	client gojaqpot.IJaqpotClient
+	opts   Options
+}
+
+// NewHandler creates a Handler making predictions with client. Mount it on PredictPath, e.g.
+// mux.Handle(gateway.PredictPath, gateway.NewHandler(client, opts)).
+func NewHandler(client gojaqpot.IJaqpotClient, opts Options) *Handler {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if opts.MaxRows <= 0 {
+		opts.MaxRows = DefaultMaxRows
+	}
+	return &Handler{client: client, opts: opts}
+}
+
+// ServeHTTP handles POST /predict/{modelId}.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	modelID, ok := modelIDOf(r.URL.Path, PredictPath)
+	if !ok {
+		writeError(w, http.StatusNotFound, "NotFound", "no such endpoint, use POST "+PredictPath+"{modelId}")
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "use POST")
+		return
+	}
+
+	token, ok := h.token(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized", "a bearer token is required")
+		return
+	}
+
+	rows, status, err := h.readRows(w, r)
+	if err != nil {
+		writeError(w, status, statusCode(status), err.Error())
+		return
+	}
+
+	prediction, err := h.client.Predict(modelID, rows, token)
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{
+		ModelID:     modelID,
+		DatasetID:   prediction.DatasetID,
+		Predictions: prediction.Predictions,
+		Domain:      prediction.Domain,
+	})
+}
+
+// modelIDOf returns the model ID of a path prefix + "{modelId}".
+func modelIDOf(path string, prefix string) (modelID string, ok bool) {
+	if !strings.HasPrefix(path, prefix) {
+		return "", false
+	}
+	modelID = strings.TrimPrefix(path, prefix)
+	if modelID == "" || strings.Contains(modelID, "/") {
+		return "", false
+	}
+	return modelID, true
+}
+
+// token returns the Jaqpot token of a request: the caller's bearer token, unless ignored, else the service token.
+func (h *Handler) token(r *http.Request) (token string, ok bool) {
+	if !h.opts.IgnoreCallerToken {
+		const prefix = "Bearer "
+		if auth := r.Header.Get("Authorization"); len(auth) > len(prefix) && strings.EqualFold(auth[:len(prefix)], prefix) {
+			return strings.TrimSpace(auth[len(prefix):]), true
+		}
+	}
+	return h.opts.ServiceToken, h.opts.ServiceToken != ""
+}
+
+// readRows decodes the JSON array of rows of a request body, within the size and row limits.
+// On error it returns the HTTP status to respond with.
+func (h *Handler) readRows(w http.ResponseWriter, r *http.Request) (rows []map[string]interface{}, status int, err error) {
+	body := http.MaxBytesReader(w, r.Body, h.opts.MaxBodyBytes)
+	dec := json.NewDecoder(body)
+	dec.UseNumber()
+
+	if err = dec.Decode(&rows); err != nil {
+		if tooLarge(err) {
+			return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds %d bytes", h.opts.MaxBodyBytes)
+		}
+		if err == io.EOF {
+			return nil, http.StatusBadRequest, errors.New("request body is empty, expected a JSON array of rows")
+		}
+		return nil, http.StatusBadRequest, fmt.Errorf("expected a JSON array of rows: %s", err.Error())
+	}
+	if err = dec.Decode(&struct{}{}); err != io.EOF {
+		if tooLarge(err) {
+			return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds %d bytes", h.opts.MaxBodyBytes)
+		}
+		return nil, http.StatusBadRequest, errors.New("unexpected data after the array of rows")
+	}
+
+	if len(rows) == 0 {
+		return nil, http.StatusBadRequest, errors.New("no rows to predict")
+	}
+	if len(rows) > h.opts.MaxRows {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("%d rows exceed the limit of %d", len(rows), h.opts.MaxRows)
+	}
+	for i, row := range rows {
+		if row == nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("row %d is not a JSON object", i)
+		}
+	}
+	return rows, http.StatusOK, nil
+}
+
+// tooLarge tells whether err was returned by http.MaxBytesReader for a body over its limit.
+func tooLarge(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "request body too large")
+}
+
+// writeErr writes the JSON error response of an error returned by the client.
+func writeErr(w http.ResponseWriter, err error) {
+	status, resp := errorResponse(err)
+	writeJSON(w, status, resp)
+}
+
+// errorResponse maps an error returned by the client to a status and an error response: validation
+// errors are 422, Jaqpot error reports keep their status (server errors become 502) and other failures are 502.
+func errorResponse(err error) (status int, resp ErrorResponse) {
+	var invalid *dataset.ValidationError
+	if errors.As(err, &invalid) {
+		resp = ErrorResponse{
+			Code:       "InvalidInput",
+			Message:    err.Error(),
+			HTTPStatus: http.StatusUnprocessableEntity,
+		}
+		for _, rowErr := range invalid.Errors {
+			resp.Errors = append(resp.Errors, InvalidValue{Row: rowErr.Row, Feature: rowErr.Feature, Message: rowErr.Message})
+		}
+		return resp.HTTPStatus, resp
+	}
+
+	var apiErr *models.APIError
+	if errors.As(err, &apiErr) {
+		status = apiErr.StatusCode
+		if status < 400 || status >= 500 {
+			status = http.StatusBadGateway
+		}
+		code := apiErr.Report.Code
+		if code == "" {
+			code = statusCode(status)
+		}
+		return status, ErrorResponse{
+			Code:       code,
+			Message:    apiErr.Error(),
+			Details:    apiErr.Report.Details,
+			HTTPStatus: status,
+		}
+	}
+
+	code := "PredictionFailed"
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		code = "JaqpotUnreachable"
+	}
+	return http.StatusBadGateway, ErrorResponse{Code: code, Message: err.Error(), HTTPStatus: http.StatusBadGateway}
+}
+
+// statusCode returns the error code of an HTTP status, e.g. "BadGateway".
+func statusCode(status int) string {
+	return strings.Replace(http.StatusText(status), " ", "", -1)
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, ErrorResponse{Code: code, Message: message, HTTPStatus: status})
+}
+
+// writeJSON writes v as the JSON body of a response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
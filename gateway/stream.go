@@ -0,0 +1,138 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	gojaqpot "github.com/euclia/gojaqpot"
+	"github.com/euclia/gojaqpot/models"
+)
+
+// StreamPath is the path prefix of streamed prediction requests, followed by the model ID.
+const StreamPath = "/predict-stream/"
+
+// Server-Sent Event names of a streamed prediction.
+const (
+	// EventStatus carries a Progress every time the task's status or percentage changes.
+	EventStatus = "status"
+	// EventResult carries the Response of a finished prediction; it is the last event.
+	EventResult = "result"
+	// EventError carries the ErrorResponse of a failed prediction; it is the last event.
+	EventError = "error"
+)
+
+// Progress is the data of a status event.
+type Progress struct {
+	TaskID              string            `json:"taskId"`
+	Status              models.TaskStatus `json:"status"`
+	PercentageCompleted float32           `json:"percentageCompleted"`
+}
+
+// StreamHandler makes predictions like Handler, but streams the progress of the prediction task
+// as Server-Sent Events (text/event-stream) and ends the stream with the result.
+// Requests are POST StreamPath + "{modelId}" with the same body as Handler's; since EventSource
+// cannot POST, browsers read the stream with fetch.
+type StreamHandler struct {
+	Handler
+}
+
+// NewStreamHandler creates a StreamHandler making predictions with client. Mount it on StreamPath, e.g.
+// mux.Handle(gateway.StreamPath, gateway.NewStreamHandler(client, opts)).
+func NewStreamHandler(client gojaqpot.IJaqpotClient, opts Options) *StreamHandler {
+	return &StreamHandler{Handler: *NewHandler(client, opts)}
+}
+
+// ServeHTTP handles POST /predict-stream/{modelId}. Errors found before the prediction task starts,
+// invalid rows included, are plain JSON error responses; later ones are error events. Polling stops when the caller disconnects.
+func (h *StreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	modelID, ok := modelIDOf(r.URL.Path, StreamPath)
+	if !ok {
+		writeError(w, http.StatusNotFound, "NotFound", "no such endpoint, use POST "+StreamPath+"{modelId}")
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "use POST")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "StreamingUnsupported", "the server cannot stream responses")
+		return
+	}
+
+	token, ok := h.token(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized", "a bearer token is required")
+		return
+	}
+
+	rows, status, err := h.readRows(w, r)
+	if err != nil {
+		writeError(w, status, statusCode(status), err.Error())
+		return
+	}
+
+	// The stream starts with the first status event, so that a prediction refused before its task
+	// starts (e.g. for invalid rows) still gets a plain error response.
+	started := false
+	start := func() {
+		if started {
+			return
+		}
+		started = true
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+	}
+
+	var last Progress
+	onUpdate := func(polled models.Task) {
+		progress := Progress{TaskID: polled.SlashID, Status: polled.HasStatus, PercentageCompleted: polled.PercentageCompleted}
+		if progress.TaskID == "" {
+			progress.TaskID = polled.ID
+		}
+		if progress == last {
+			return
+		}
+		last = progress
+		start()
+		writeEvent(w, EventStatus, progress)
+		flusher.Flush()
+	}
+
+	prediction, err := h.client.PredictContext(r.Context(), modelID, rows, onUpdate, token)
+	if r.Context().Err() != nil {
+		// The caller is gone; there is no one to tell.
+		return
+	}
+	if err != nil && !started {
+		writeErr(w, err)
+		return
+	}
+	start()
+	if err != nil {
+		_, resp := errorResponse(err)
+		writeEvent(w, EventError, resp)
+	} else {
+		writeEvent(w, EventResult, Response{
+			ModelID:     modelID,
+			DatasetID:   prediction.DatasetID,
+			Predictions: prediction.Predictions,
+			Domain:      prediction.Domain,
+		})
+	}
+	flusher.Flush()
+}
+
+// writeEvent writes a Server-Sent Event with v as its JSON data.
+func writeEvent(w http.ResponseWriter, event string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		event, data = EventError, []byte(fmt.Sprintf(`{"code":"EncodingFailed","message":%q,"httpStatus":500}`, err.Error()))
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
@@ -2,4 +2,12 @@ module github.com/euclia/gojaqpot
 
 go 1.15
 
-require github.com/euclia/goJaqpot v0.0.0-20201229120154-2198a0de50ae
+require (
+	github.com/euclia/goJaqpot v0.0.0-20201229120154-2198a0de50ae
+	golang.org/x/net v0.11.0 // indirect
+	golang.org/x/sys v0.10.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
+	google.golang.org/grpc v1.54.0
+	google.golang.org/protobuf v1.30.0
+)
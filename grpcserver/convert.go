@@ -0,0 +1,332 @@
+package grpcserver
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/euclia/gojaqpot/grpcserver/jaqpotpb"
+	"github.com/euclia/gojaqpot/models"
+)
+
+// MetaToProto converts entity metadata.
+func MetaToProto(meta models.MetaInfo) *jaqpotpb.Meta {
+	pb := &jaqpotpb.Meta{
+		Titles:       meta.Titles,
+		Descriptions: meta.Descriptions,
+		Creators:     meta.Creators,
+		Tags:         meta.Tags,
+		Comments:     meta.Comments,
+		HasSources:   meta.HasSources,
+	}
+	if meta.Date != nil && !meta.Date.IsZero() {
+		pb.Date = timestamppb.New(meta.Date.Time)
+	}
+	return pb
+}
+
+// MetaFromProto converts entity metadata.
+func MetaFromProto(pb *jaqpotpb.Meta) (meta models.MetaInfo) {
+	if pb == nil {
+		return meta
+	}
+	meta = models.MetaInfo{
+		Titles:       pb.GetTitles(),
+		Descriptions: pb.GetDescriptions(),
+		Creators:     pb.GetCreators(),
+		Tags:         pb.GetTags(),
+		Comments:     pb.GetComments(),
+		HasSources:   pb.GetHasSources(),
+	}
+	if pb.GetDate() != nil {
+		meta.Date = models.NewDate(pb.GetDate().AsTime())
+	}
+	return meta
+}
+
+// ModelToProto converts a model. Its actual and PMML models are left out; feature names are taken
+// from its additional info when it has them.
+func ModelToProto(model models.Model) (pb *jaqpotpb.Model, err error) {
+	pb = &jaqpotpb.Model{
+		Id:                  entityID(model.ID, model.SlashID),
+		Meta:                MetaToProto(model.Meta),
+		IndependentFeatures: model.IndependentFeatures,
+		PredictedFeatures:   model.PredictedFeatures,
+		DependentFeatures:   model.DependentFeatures,
+		AlgorithmId:         entityID(model.Algorithm.ID, model.Algorithm.SlashID),
+		DatasetUri:          model.DatasetURI,
+		DoaModel:            model.DoaModel,
+		Reliability:         model.Reliability,
+		Visible:             model.Visible,
+	}
+	if len(model.Parameters) > 0 {
+		if pb.Parameters, err = toStruct(model.Parameters); err != nil {
+			return nil, fmt.Errorf("model parameters: %s", err.Error())
+		}
+	}
+	if info, err := model.DecodeAdditionalInfo(); err == nil {
+		pb.IndependentFeatureNames = info.IndependentFeatures
+		pb.PredictedFeatureNames = info.PredictedFeatures
+	}
+	return pb, nil
+}
+
+// ModelFromProto converts a model.
+func ModelFromProto(pb *jaqpotpb.Model) (model models.Model) {
+	if pb == nil {
+		return model
+	}
+	model = models.Model{
+		ID:                  pb.GetId(),
+		Meta:                MetaFromProto(pb.GetMeta()),
+		IndependentFeatures: pb.GetIndependentFeatures(),
+		PredictedFeatures:   pb.GetPredictedFeatures(),
+		DependentFeatures:   pb.GetDependentFeatures(),
+		Algorithm:           models.Algorithm{ID: pb.GetAlgorithmId()},
+		DatasetURI:          pb.GetDatasetUri(),
+		DoaModel:            pb.GetDoaModel(),
+		Reliability:         pb.GetReliability(),
+		Visible:             pb.GetVisible(),
+	}
+	if pb.GetParameters() != nil {
+		model.Parameters = pb.GetParameters().AsMap()
+	}
+	if len(pb.GetIndependentFeatureNames()) > 0 || len(pb.GetPredictedFeatureNames()) > 0 {
+		model.SetAdditionalInfo(models.AdditionalInfo{
+			IndependentFeatures: pb.GetIndependentFeatureNames(),
+			PredictedFeatures:   pb.GetPredictedFeatureNames(),
+		})
+	}
+	return model
+}
+
+// DatasetToProto converts a dataset.
+func DatasetToProto(dataset models.Dataset) (pb *jaqpotpb.Dataset, err error) {
+	pb = &jaqpotpb.Dataset{
+		Id:           dataset.SlashID,
+		Meta:         MetaToProto(dataset.Meta),
+		DatasetUri:   dataset.DatasetURI,
+		ByModel:      dataset.ByModel,
+		TotalRows:    int32(dataset.TotalRows),
+		TotalColumns: int32(dataset.TotalColumns),
+	}
+	for _, feat := range dataset.Features {
+		pb.Features = append(pb.Features, &jaqpotpb.FeatureInfo{
+			Key:      feat.Key,
+			Name:     feat.Name,
+			Units:    feat.Units,
+			Category: feat.Category,
+			Uri:      feat.URI,
+		})
+	}
+	for i, entry := range dataset.DataEntry {
+		values, err := toStruct(entry.Values)
+		if err != nil {
+			return nil, fmt.Errorf("data entry %d: %s", i, err.Error())
+		}
+		pb.DataEntry = append(pb.DataEntry, &jaqpotpb.DataEntry{
+			EntryName: entry.EntryID.Name,
+			EntryUri:  entry.EntryID.URI,
+			Values:    values,
+		})
+	}
+	return pb, nil
+}
+
+// DatasetFromProto converts a dataset.
+func DatasetFromProto(pb *jaqpotpb.Dataset) (dataset models.Dataset) {
+	if pb == nil {
+		return dataset
+	}
+	dataset = models.Dataset{
+		SlashID:      pb.GetId(),
+		Meta:         MetaFromProto(pb.GetMeta()),
+		DatasetURI:   pb.GetDatasetUri(),
+		ByModel:      pb.GetByModel(),
+		TotalRows:    int(pb.GetTotalRows()),
+		TotalColumns: int(pb.GetTotalColumns()),
+	}
+	for _, feat := range pb.GetFeatures() {
+		dataset.Features = append(dataset.Features, models.FeatureInfo{
+			Key:      feat.GetKey(),
+			Name:     feat.GetName(),
+			Units:    feat.GetUnits(),
+			Category: feat.GetCategory(),
+			URI:      feat.GetUri(),
+		})
+	}
+	for _, entry := range pb.GetDataEntry() {
+		dataset.DataEntry = append(dataset.DataEntry, models.DataEntry{
+			EntryID: models.EntryID{Name: entry.GetEntryName(), URI: entry.GetEntryUri()},
+			Values:  entry.GetValues().AsMap(),
+		})
+	}
+	return dataset
+}
+
+// TaskToProto converts a task.
+func TaskToProto(task models.Task) *jaqpotpb.Task {
+	pb := &jaqpotpb.Task{
+		Id:                  entityID(task.ID, task.SlashID),
+		Meta:                MetaToProto(task.Meta),
+		Status:              string(task.HasStatus),
+		PercentageCompleted: task.PercentageCompleted,
+		Result:              task.Result,
+		ResultUri:           task.ResultURI,
+		Type:                task.Type,
+		Duration:            task.Duration,
+	}
+	if report := task.ErrorReport; report.Message != "" || report.Code != "" {
+		pb.ErrorReport = &jaqpotpb.ErrorReport{
+			Code:       report.Code,
+			Message:    report.Message,
+			Details:    report.Details,
+			HttpStatus: int32(report.HTTPStatus),
+		}
+	}
+	return pb
+}
+
+// TaskFromProto converts a task.
+func TaskFromProto(pb *jaqpotpb.Task) (task models.Task) {
+	if pb == nil {
+		return task
+	}
+	task = models.Task{
+		ID:                  pb.GetId(),
+		SlashID:             pb.GetId(),
+		Meta:                MetaFromProto(pb.GetMeta()),
+		HasStatus:           models.TaskStatus(pb.GetStatus()),
+		PercentageCompleted: pb.GetPercentageCompleted(),
+		Result:              pb.GetResult(),
+		ResultURI:           pb.GetResultUri(),
+		Type:                pb.GetType(),
+		Duration:            pb.GetDuration(),
+	}
+	if report := pb.GetErrorReport(); report != nil {
+		task.ErrorReport = models.ErrorReport{
+			Code:       report.GetCode(),
+			Message:    report.GetMessage(),
+			Details:    report.GetDetails(),
+			HTTPStatus: int(report.GetHttpStatus()),
+		}
+	}
+	return task
+}
+
+// DoaToProto converts a domain of applicability.
+func DoaToProto(doa models.Doa) *jaqpotpb.Doa {
+	pb := &jaqpotpb.Doa{
+		Id:      entityID(doa.ID, doa.SlashID),
+		Meta:    MetaToProto(doa.Meta),
+		ModelId: doa.ModelID,
+		AValue:  doa.AValue,
+	}
+	for _, row := range doa.DoaMatrix {
+		pb.DoaMatrix = append(pb.DoaMatrix, &jaqpotpb.DoaRow{Values: row})
+	}
+	return pb
+}
+
+// DoaFromProto converts a domain of applicability.
+func DoaFromProto(pb *jaqpotpb.Doa) (doa models.Doa) {
+	if pb == nil {
+		return doa
+	}
+	doa = models.Doa{
+		ID:      pb.GetId(),
+		Meta:    MetaFromProto(pb.GetMeta()),
+		ModelID: pb.GetModelId(),
+		AValue:  pb.GetAValue(),
+	}
+	for _, row := range pb.GetDoaMatrix() {
+		doa.DoaMatrix = append(doa.DoaMatrix, row.GetValues())
+	}
+	return doa
+}
+
+// PredictionToProto converts a prediction.
+func PredictionToProto(prediction models.Prediction) (pb *jaqpotpb.Prediction, err error) {
+	pb = &jaqpotpb.Prediction{
+		ModelId:   prediction.ModelID,
+		DatasetId: prediction.DatasetID,
+	}
+	if pb.Data, err = toStructs(prediction.Data); err != nil {
+		return nil, fmt.Errorf("prediction data: %s", err.Error())
+	}
+	if pb.Predictions, err = toStructs(prediction.Predictions); err != nil {
+		return nil, fmt.Errorf("predictions: %s", err.Error())
+	}
+	for _, domain := range prediction.Domain {
+		pb.Domain = append(pb.Domain, &jaqpotpb.DomainAssessment{Leverage: domain.Leverage, InDomain: domain.InDomain})
+	}
+	return pb, nil
+}
+
+// PredictionFromProto converts a prediction.
+func PredictionFromProto(pb *jaqpotpb.Prediction) (prediction models.Prediction) {
+	if pb == nil {
+		return prediction
+	}
+	prediction = models.Prediction{
+		ModelID:     pb.GetModelId(),
+		DatasetID:   pb.GetDatasetId(),
+		Data:        RowsFromProto(pb.GetData()),
+		Predictions: RowsFromProto(pb.GetPredictions()),
+	}
+	for _, domain := range pb.GetDomain() {
+		prediction.Domain = append(prediction.Domain, models.DomainAssessment{Leverage: domain.GetLeverage(), InDomain: domain.GetInDomain()})
+	}
+	return prediction
+}
+
+// RowsFromProto converts rows of values keyed by feature name.
+func RowsFromProto(rows []*structpb.Struct) []map[string]interface{} {
+	if rows == nil {
+		return nil
+	}
+	converted := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		converted[i] = row.AsMap()
+	}
+	return converted
+}
+
+// toStructs converts rows of values to structs.
+func toStructs(rows []map[string]interface{}) (structs []*structpb.Struct, err error) {
+	for i, row := range rows {
+		converted, err := toStruct(row)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %s", i, err.Error())
+		}
+		structs = append(structs, converted)
+	}
+	return structs, nil
+}
+
+// toStruct converts a map of decoded JSON values to a struct. Values structpb does not take
+// (e.g. json.Number or typed slices) go through a JSON round trip.
+func toStruct(values map[string]interface{}) (*structpb.Struct, error) {
+	if converted, err := structpb.NewStruct(values); err == nil {
+		return converted, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	var plain map[string]interface{}
+	if err = json.Unmarshal(data, &plain); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(plain)
+}
+
+// entityID returns the ID of an entity, whichever member it was received in.
+func entityID(id string, slashID string) string {
+	if id != "" {
+		return id
+	}
+	return slashID
+}
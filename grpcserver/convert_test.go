@@ -0,0 +1,104 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/euclia/gojaqpot/dataset"
+	"github.com/euclia/gojaqpot/models"
+)
+
+func TestPredictionRoundTrip(t *testing.T) {
+	prediction := models.Prediction{
+		ModelID:     "m1",
+		DatasetID:   "d1",
+		Data:        []map[string]interface{}{{"MW": json.Number("180.16"), "Name": "glucose", "Ring": []int{6}}},
+		Predictions: []map[string]interface{}{{"LogP": -3.24, "Class": nil}},
+		Domain:      []models.DomainAssessment{{Leverage: 0.25, InDomain: true}},
+	}
+
+	pb, err := PredictionToProto(prediction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := PredictionFromProto(pb)
+
+	want := models.Prediction{
+		ModelID:     "m1",
+		DatasetID:   "d1",
+		Data:        []map[string]interface{}{{"MW": 180.16, "Name": "glucose", "Ring": []interface{}{6.0}}},
+		Predictions: []map[string]interface{}{{"LogP": -3.24, "Class": nil}},
+		Domain:      prediction.Domain,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip =\n%#v\nwant\n%#v", got, want)
+	}
+
+	if _, err = PredictionToProto(models.Prediction{Predictions: []map[string]interface{}{{"f": make(chan int)}}}); err == nil {
+		t.Error("no error for a value that is not JSON")
+	}
+}
+
+func TestModelRoundTrip(t *testing.T) {
+	model := models.Model{
+		SlashID:             "m1",
+		Meta:                models.MetaInfo{Titles: []string{"LogP"}, Date: models.NewDate(time.Date(2020, 12, 29, 12, 1, 54, 0, time.UTC))},
+		IndependentFeatures: []string{"feature/f1"},
+		PredictedFeatures:   []string{"feature/f2"},
+		Algorithm:           models.Algorithm{SlashID: "python-pls"},
+		Parameters:          map[string]interface{}{"components": 2.0},
+	}
+	model.SetAdditionalInfo(models.AdditionalInfo{IndependentFeatures: map[string]string{"feature/f1": "MW"}})
+
+	pb, err := ModelToProto(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pb.GetId() != "m1" || pb.GetAlgorithmId() != "python-pls" {
+		t.Errorf("IDs = %q and %q, want those given in _id", pb.GetId(), pb.GetAlgorithmId())
+	}
+
+	got := ModelFromProto(pb)
+	if got.ID != "m1" || !got.Meta.Date.Equal(model.Meta.Date.Time) || !reflect.DeepEqual(got.Parameters, model.Parameters) {
+		t.Errorf("round trip = %+v", got)
+	}
+	if info, err := got.DecodeAdditionalInfo(); err != nil || info.IndependentFeatures["feature/f1"] != "MW" {
+		t.Errorf("additional info = %+v, %v; want the feature names", info, err)
+	}
+}
+
+func TestMetaUnknownDate(t *testing.T) {
+	var date models.Date
+	if err := json.Unmarshal([]byte(`"Dec 29, 2020 12:01:54 PM"`), &date); err != nil {
+		t.Fatal(err)
+	}
+	if pb := MetaToProto(models.MetaInfo{Date: &date}); pb.GetDate() != nil {
+		t.Errorf("date = %v, want none for a date in an unknown format", pb.GetDate())
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{&dataset.ValidationError{Errors: []dataset.RowError{{Row: 0, Feature: "MW", Message: "missing value"}}}, codes.InvalidArgument},
+		{fmt.Errorf("creating dataset: %w", &dataset.ValidationError{}), codes.InvalidArgument},
+		{models.NewAPIError(404, models.ErrorReport{Message: "no such model"}), codes.NotFound},
+		{models.NewAPIError(401, models.ErrorReport{}), codes.Unauthenticated},
+		{models.NewAPIError(503, models.ErrorReport{}), codes.Unavailable},
+		{context.Canceled, codes.Canceled},
+	}
+	for _, test := range tests {
+		if got := status.Code(statusError(test.err)); got != test.want {
+			t.Errorf("statusError(%v) = %s, want %s", test.err, got, test.want)
+		}
+	}
+}
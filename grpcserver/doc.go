@@ -0,0 +1,17 @@
+// Package grpcserver serves Jaqpot model metadata and predictions over gRPC, fronting an IJaqpotClient.
+//
+// The service and messages are defined in jaqpotpb/jaqpot.proto; conversion functions map the
+// messages to and from the models structs. The generated code in jaqpotpb is committed; regenerate
+// it with go generate ./grpcserver after changing the proto file (needs protoc, protoc-gen-go and
+// protoc-gen-go-grpc).
+//
+// Usage:
+//
+//	s := grpc.NewServer()
+//	grpcserver.Register(s, grpcserver.NewServer(client, grpcserver.Options{ServiceToken: token}))
+//	s.Serve(listener)
+//
+// Callers pass their Jaqpot token in the "authorization" metadata ("Bearer <token>").
+package grpcserver
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative jaqpotpb/jaqpot.proto
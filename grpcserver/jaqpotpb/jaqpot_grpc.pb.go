@@ -0,0 +1,222 @@
+// Protobuf definitions of the Jaqpot entities and of a prediction service fronting the Go client.
+// Generate the Go code with `go generate ./grpcserver` (needs protoc, protoc-gen-go and protoc-gen-go-grpc).
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: jaqpotpb/jaqpot.proto
+
+package jaqpotpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Jaqpot_GetModel_FullMethodName      = "/jaqpot.v1.Jaqpot/GetModel"
+	Jaqpot_Predict_FullMethodName       = "/jaqpot.v1.Jaqpot/Predict"
+	Jaqpot_PredictStream_FullMethodName = "/jaqpot.v1.Jaqpot/PredictStream"
+)
+
+// JaqpotClient is the client API for Jaqpot service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type JaqpotClient interface {
+	// GetModel returns a model by ID.
+	GetModel(ctx context.Context, in *GetModelRequest, opts ...grpc.CallOption) (*Model, error)
+	// Predict predicts rows with a model and returns once the prediction task has finished.
+	Predict(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (*Prediction, error)
+	// PredictStream predicts rows with a model, streaming the prediction task as it progresses
+	// and ending with the prediction.
+	PredictStream(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (Jaqpot_PredictStreamClient, error)
+}
+
+type jaqpotClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJaqpotClient(cc grpc.ClientConnInterface) JaqpotClient {
+	return &jaqpotClient{cc}
+}
+
+func (c *jaqpotClient) GetModel(ctx context.Context, in *GetModelRequest, opts ...grpc.CallOption) (*Model, error) {
+	out := new(Model)
+	err := c.cc.Invoke(ctx, Jaqpot_GetModel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jaqpotClient) Predict(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (*Prediction, error) {
+	out := new(Prediction)
+	err := c.cc.Invoke(ctx, Jaqpot_Predict_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jaqpotClient) PredictStream(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (Jaqpot_PredictStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Jaqpot_ServiceDesc.Streams[0], Jaqpot_PredictStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jaqpotPredictStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Jaqpot_PredictStreamClient interface {
+	Recv() (*PredictEvent, error)
+	grpc.ClientStream
+}
+
+type jaqpotPredictStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *jaqpotPredictStreamClient) Recv() (*PredictEvent, error) {
+	m := new(PredictEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// JaqpotServer is the server API for Jaqpot service.
+// All implementations must embed UnimplementedJaqpotServer
+// for forward compatibility
+type JaqpotServer interface {
+	// GetModel returns a model by ID.
+	GetModel(context.Context, *GetModelRequest) (*Model, error)
+	// Predict predicts rows with a model and returns once the prediction task has finished.
+	Predict(context.Context, *PredictRequest) (*Prediction, error)
+	// PredictStream predicts rows with a model, streaming the prediction task as it progresses
+	// and ending with the prediction.
+	PredictStream(*PredictRequest, Jaqpot_PredictStreamServer) error
+	mustEmbedUnimplementedJaqpotServer()
+}
+
+// UnimplementedJaqpotServer must be embedded to have forward compatible implementations.
+type UnimplementedJaqpotServer struct {
+}
+
+func (UnimplementedJaqpotServer) GetModel(context.Context, *GetModelRequest) (*Model, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetModel not implemented")
+}
+func (UnimplementedJaqpotServer) Predict(context.Context, *PredictRequest) (*Prediction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Predict not implemented")
+}
+func (UnimplementedJaqpotServer) PredictStream(*PredictRequest, Jaqpot_PredictStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method PredictStream not implemented")
+}
+func (UnimplementedJaqpotServer) mustEmbedUnimplementedJaqpotServer() {}
+
+// UnsafeJaqpotServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JaqpotServer will
+// result in compilation errors.
+type UnsafeJaqpotServer interface {
+	mustEmbedUnimplementedJaqpotServer()
+}
+
+func RegisterJaqpotServer(s grpc.ServiceRegistrar, srv JaqpotServer) {
+	s.RegisterService(&Jaqpot_ServiceDesc, srv)
+}
+
+func _Jaqpot_GetModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetModelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JaqpotServer).GetModel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Jaqpot_GetModel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JaqpotServer).GetModel(ctx, req.(*GetModelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jaqpot_Predict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PredictRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JaqpotServer).Predict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Jaqpot_Predict_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JaqpotServer).Predict(ctx, req.(*PredictRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jaqpot_PredictStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PredictRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JaqpotServer).PredictStream(m, &jaqpotPredictStreamServer{stream})
+}
+
+type Jaqpot_PredictStreamServer interface {
+	Send(*PredictEvent) error
+	grpc.ServerStream
+}
+
+type jaqpotPredictStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *jaqpotPredictStreamServer) Send(m *PredictEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Jaqpot_ServiceDesc is the grpc.ServiceDesc for Jaqpot service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Jaqpot_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jaqpot.v1.Jaqpot",
+	HandlerType: (*JaqpotServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetModel",
+			Handler:    _Jaqpot_GetModel_Handler,
+		},
+		{
+			MethodName: "Predict",
+			Handler:    _Jaqpot_Predict_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PredictStream",
+			Handler:       _Jaqpot_PredictStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "jaqpotpb/jaqpot.proto",
+}
@@ -0,0 +1,204 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	gojaqpot "github.com/euclia/gojaqpot"
+	"github.com/euclia/gojaqpot/dataset"
+	"github.com/euclia/gojaqpot/grpcserver/jaqpotpb"
+	"github.com/euclia/gojaqpot/models"
+)
+
+// DefaultMaxRows is the default limit of rows in one prediction request.
+const DefaultMaxRows = 1000
+
+// Options configures a Server.
+type Options struct {
+	// ServiceToken is the Jaqpot token used for callers that send no "authorization" metadata.
+	// Without it such callers are rejected.
+	ServiceToken string
+	// IgnoreCallerToken makes every call use ServiceToken, instead of forwarding the caller's token.
+	IgnoreCallerToken bool
+	// MaxRows limits the number of rows of a prediction request (defaults to DefaultMaxRows).
+	MaxRows int
+}
+
+// Server implements the Jaqpot gRPC service with an IJaqpotClient.
+type Server struct {
+	jaqpotpb.UnimplementedJaqpotServer
+
+	client gojaqpot.IJaqpotClient
+	opts   Options
+}
+
+// NewServer creates a Server making its calls with client.
+func NewServer(client gojaqpot.IJaqpotClient, opts Options) *Server {
+	if opts.MaxRows <= 0 {
+		opts.MaxRows = DefaultMaxRows
+	}
+	return &Server{client: client, opts: opts}
+}
+
+// Register registers srv on a gRPC server.
+func Register(s *grpc.Server, srv *Server) {
+	jaqpotpb.RegisterJaqpotServer(s, srv)
+}
+
+// GetModel returns a model by ID.
+func (s *Server) GetModel(ctx context.Context, req *jaqpotpb.GetModelRequest) (*jaqpotpb.Model, error) {
+	if req.GetModelId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "model_id is required")
+	}
+	token, err := s.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := s.client.GetModel(req.GetModelId(), token)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	pb, err := ModelToProto(model)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return pb, nil
+}
+
+// Predict predicts rows with a model and returns once the prediction task has finished.
+func (s *Server) Predict(ctx context.Context, req *jaqpotpb.PredictRequest) (*jaqpotpb.Prediction, error) {
+	rows, token, err := s.predictRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	prediction, err := s.client.PredictContext(ctx, req.GetModelId(), rows, nil, token)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	pb, err := PredictionToProto(prediction)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return pb, nil
+}
+
+// PredictStream predicts rows with a model, sending the prediction task every time its status or
+// percentage changes and then the prediction. Polling stops when the caller goes away.
+func (s *Server) PredictStream(req *jaqpotpb.PredictRequest, stream jaqpotpb.Jaqpot_PredictStreamServer) error {
+	ctx := stream.Context()
+	rows, token, err := s.predictRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	var last models.Task
+	var sendErr error
+	onUpdate := func(polled models.Task) {
+		if sendErr != nil || (polled.HasStatus == last.HasStatus && polled.PercentageCompleted == last.PercentageCompleted) {
+			return
+		}
+		last = polled
+		sendErr = stream.Send(&jaqpotpb.PredictEvent{Event: &jaqpotpb.PredictEvent_Progress{Progress: TaskToProto(polled)}})
+	}
+
+	prediction, err := s.client.PredictContext(ctx, req.GetModelId(), rows, onUpdate, token)
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return statusError(err)
+	}
+	pb, err := PredictionToProto(prediction)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return stream.Send(&jaqpotpb.PredictEvent{Event: &jaqpotpb.PredictEvent_Result{Result: pb}})
+}
+
+// predictRequest checks a prediction request and returns its rows and the Jaqpot token to use.
+func (s *Server) predictRequest(ctx context.Context, req *jaqpotpb.PredictRequest) (rows []map[string]interface{}, token string, err error) {
+	if req.GetModelId() == "" {
+		return nil, "", status.Error(codes.InvalidArgument, "model_id is required")
+	}
+	if len(req.GetRows()) == 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "no rows to predict")
+	}
+	if len(req.GetRows()) > s.opts.MaxRows {
+		return nil, "", status.Errorf(codes.ResourceExhausted, "%d rows exceed the limit of %d", len(req.GetRows()), s.opts.MaxRows)
+	}
+	if token, err = s.token(ctx); err != nil {
+		return nil, "", err
+	}
+
+	return RowsFromProto(req.GetRows()), token, nil
+}
+
+// token returns the Jaqpot token of a call: the caller's, unless ignored, else the service token.
+func (s *Server) token(ctx context.Context) (string, error) {
+	if !s.opts.IgnoreCallerToken {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, auth := range md.Get("authorization") {
+			const prefix = "bearer "
+			if len(auth) > len(prefix) && strings.EqualFold(auth[:len(prefix)], prefix) {
+				return strings.TrimSpace(auth[len(prefix):]), nil
+			}
+		}
+	}
+	if s.opts.ServiceToken == "" {
+		return "", status.Error(codes.Unauthenticated, "a bearer token is required")
+	}
+	return s.opts.ServiceToken, nil
+}
+
+// statusError maps an error returned by the client to a gRPC status.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	var invalid *dataset.ValidationError
+	if errors.As(err, &invalid) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var apiErr *models.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest, http.StatusUnprocessableEntity:
+			return status.Error(codes.InvalidArgument, err.Error())
+		case http.StatusUnauthorized:
+			return status.Error(codes.Unauthenticated, err.Error())
+		case http.StatusForbidden:
+			return status.Error(codes.PermissionDenied, err.Error())
+		case http.StatusNotFound:
+			return status.Error(codes.NotFound, err.Error())
+		case http.StatusConflict:
+			return status.Error(codes.AlreadyExists, err.Error())
+		case http.StatusTooManyRequests:
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if apiErr.StatusCode >= 500 {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	// Other errors are failed prediction tasks.
+	return status.Error(codes.Internal, err.Error())
+}
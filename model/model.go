@@ -2,8 +2,6 @@ package model
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -27,7 +25,6 @@ func GetModel(modelID string, AuthToken string, BaseURL string, HTTPClient *http
 	var returnModel models.Model
 
 	if err != nil {
-		fmt.Printf(err.Error())
 		return returnModel, err
 	}
 
@@ -35,7 +32,7 @@ func GetModel(modelID string, AuthToken string, BaseURL string, HTTPClient *http
 		var errorReport models.ErrorReport
 		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
 		defer resp.Body.Close()
-		err = errors.New(errorReport.Message)
+		err = models.NewAPIError(resp.StatusCode, errorReport)
 		return returnModel, err
 	}
 	defer resp.Body.Close()
@@ -61,7 +58,6 @@ func GetMyModels(min int, max int, AuthToken string, BaseURL string, HTTPClient
 	var returnModels models.Models
 
 	if err != nil {
-		fmt.Printf(err.Error())
 		return returnModels, err
 	}
 
@@ -69,7 +65,7 @@ func GetMyModels(min int, max int, AuthToken string, BaseURL string, HTTPClient
 		var errorReport models.ErrorReport
 		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
 		defer resp.Body.Close()
-		err = errors.New(errorReport.Message)
+		err = models.NewAPIError(resp.StatusCode, errorReport)
 		return returnModels, err
 	}
 	defer resp.Body.Close()
@@ -99,7 +95,6 @@ func GetOrgsModels(organizationID string, min int, max int, AuthToken string, Ba
 	var returnModels models.Models
 
 	if err != nil {
-		fmt.Printf(err.Error())
 		return returnModels, err
 	}
 
@@ -107,7 +102,7 @@ func GetOrgsModels(organizationID string, min int, max int, AuthToken string, Ba
 		var errorReport models.ErrorReport
 		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
 		defer resp.Body.Close()
-		err = errors.New(errorReport.Message)
+		err = models.NewAPIError(resp.StatusCode, errorReport)
 		return returnModels, err
 	}
 	defer resp.Body.Close()
@@ -138,7 +133,6 @@ func GetOrgsModelsByTag(organizationID string, tag string, min int, max int, Aut
 	var returnModels models.Models
 
 	if err != nil {
-		fmt.Printf(err.Error())
 		return returnModels, err
 	}
 
@@ -146,7 +140,7 @@ func GetOrgsModelsByTag(organizationID string, tag string, min int, max int, Aut
 		var errorReport models.ErrorReport
 		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
 		defer resp.Body.Close()
-		err = errors.New(errorReport.Message)
+		err = models.NewAPIError(resp.StatusCode, errorReport)
 		return returnModels, err
 	}
 	defer resp.Body.Close()
@@ -175,7 +169,6 @@ func Predict(modelID string, datasetID string, AuthToken string, BaseURL string,
 	var returnTask models.Task
 
 	if err != nil {
-		fmt.Printf(err.Error())
 		return returnTask, err
 	}
 
@@ -183,7 +176,7 @@ func Predict(modelID string, datasetID string, AuthToken string, BaseURL string,
 		var errorReport models.ErrorReport
 		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
 		defer resp.Body.Close()
-		err = errors.New(errorReport.Message)
+		err = models.NewAPIError(resp.StatusCode, errorReport)
 		return returnTask, err
 	}
 	defer resp.Body.Close()
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Known sections of a model's additional info.
+const (
+	independentFeaturesKey = "independentFeatures"
+	predictedFeaturesKey   = "predictedFeatures"
+	fromUserKey            = "fromUser"
+)
+
+// AdditionalInfo structure
+type AdditionalInfo struct {
+	// IndependentFeatures maps the URI of every independent feature to its name.
+	IndependentFeatures map[string]string
+	// PredictedFeatures maps the URI of every predicted feature to its name.
+	PredictedFeatures map[string]string
+	// FromUser holds the information supplied by the user who trained the model.
+	FromUser map[string]interface{}
+	// Extra keeps the sections this structure does not know about, as sent by the server.
+	Extra map[string]json.RawMessage
+}
+
+// UnmarshalJSON decodes additional info, keeping unknown sections in Extra.
+func (info *AdditionalInfo) UnmarshalJSON(data []byte) error {
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return fmt.Errorf("additional info is not an object: %s", err.Error())
+	}
+
+	*info = AdditionalInfo{}
+	for key, raw := range sections {
+		var err error
+		switch key {
+		case independentFeaturesKey:
+			info.IndependentFeatures, err = decodeFeatureNames(raw)
+		case predictedFeaturesKey:
+			info.PredictedFeatures, err = decodeFeatureNames(raw)
+		case fromUserKey:
+			if !isNull(raw) {
+				err = json.Unmarshal(raw, &info.FromUser)
+			}
+		default:
+			if info.Extra == nil {
+				info.Extra = make(map[string]json.RawMessage)
+			}
+			info.Extra[key] = raw
+		}
+		if err != nil {
+			return fmt.Errorf("additional info %q: %s", key, err.Error())
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes additional info together with the sections kept in Extra.
+func (info AdditionalInfo) MarshalJSON() ([]byte, error) {
+	sections := make(map[string]interface{}, len(info.Extra)+3)
+	for key, raw := range info.Extra {
+		sections[key] = raw
+	}
+	if info.IndependentFeatures != nil {
+		sections[independentFeaturesKey] = info.IndependentFeatures
+	}
+	if info.PredictedFeatures != nil {
+		sections[predictedFeaturesKey] = info.PredictedFeatures
+	}
+	if info.FromUser != nil {
+		sections[fromUserKey] = info.FromUser
+	}
+	return json.Marshal(sections)
+}
+
+// DecodeAdditionalInfo decodes the model's additional info into its typed sections.
+// Unexpected shapes are reported as errors.
+func (m Model) DecodeAdditionalInfo() (info AdditionalInfo, err error) {
+	if m.AdditionalInfo == nil {
+		return info, errors.New("model has no additional info")
+	}
+	switch typed := m.AdditionalInfo.(type) {
+	case AdditionalInfo:
+		return typed, nil
+	case *AdditionalInfo:
+		if typed != nil {
+			return *typed, nil
+		}
+	}
+
+	raw, err := json.Marshal(m.AdditionalInfo)
+	if err != nil {
+		return info, err
+	}
+	err = json.Unmarshal(raw, &info)
+	return info, err
+}
+
+// SetAdditionalInfo replaces the model's additional info.
+func (m *Model) SetAdditionalInfo(info AdditionalInfo) {
+	m.AdditionalInfo = info
+}
+
+// IndependentFeatureNames returns the names of the model's independent features,
+// in the order of Model.IndependentFeatures.
+func (m Model) IndependentFeatureNames() (names []string, err error) {
+	info, err := m.DecodeAdditionalInfo()
+	if err != nil {
+		return nil, err
+	}
+	if len(info.IndependentFeatures) == 0 {
+		return nil, errors.New("model additional info has no independent features")
+	}
+
+	uris := m.IndependentFeatures
+	if len(uris) == 0 {
+		// Fall back to a stable order when the model does not list its features.
+		for uri := range info.IndependentFeatures {
+			uris = append(uris, uri)
+		}
+		sort.Strings(uris)
+	}
+
+	names = make([]string, len(uris))
+	for i, uri := range uris {
+		name, ok := info.IndependentFeatures[uri]
+		if !ok {
+			return nil, fmt.Errorf("no name for independent feature %s", uri)
+		}
+		names[i] = name
+	}
+	return names, nil
+}
+
+// DecodeActualModel decodes the model's raw actual model into v, as json.Unmarshal would.
+func (m Model) DecodeActualModel(v interface{}) error {
+	if m.ActualModel == nil {
+		return errors.New("model has no actual model")
+	}
+	raw, err := json.Marshal(m.ActualModel)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// decodeFeatureNames decodes a URI to name section, accepting names given as numbers too.
+func decodeFeatureNames(raw json.RawMessage) (map[string]string, error) {
+	if isNull(raw) {
+		return nil, nil
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(values))
+	for uri, value := range values {
+		switch v := value.(type) {
+		case string:
+			names[uri] = v
+		case float64, bool:
+			names[uri] = fmt.Sprintf("%v", v)
+		default:
+			return nil, fmt.Errorf("feature %s has a name of type %T", uri, value)
+		}
+	}
+	return names, nil
+}
+
+// isNull tells whether a raw JSON value is null.
+func isNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// dateLayouts are the textual date formats Jaqpot emits, tried in order.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000Z0700",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05.000",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"Mon Jan 02 15:04:05 MST 2006",
+	time.RFC1123,
+}
+
+// Date is a point in time as emitted by Jaqpot: epoch milliseconds or an ISO-8601 string.
+// It is encoded back in the format it was decoded from (epoch milliseconds by default).
+// A string in an unrecognised format leaves Time zero and is kept as is, see Text.
+type Date struct {
+	time.Time
+	layout string
+	text   string
+}
+
+// NewDate creates a Date, encoded as epoch milliseconds.
+func NewDate(t time.Time) *Date {
+	return &Date{Time: t}
+}
+
+// ParseDate parses a date in one of the textual formats Jaqpot emits, or as epoch milliseconds.
+func ParseDate(value string) (date Date, err error) {
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return Date{Time: fromMillis(millis)}, nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Date{Time: t, layout: layout, text: value}, nil
+		}
+	}
+	return date, fmt.Errorf("unrecognised date %q", value)
+}
+
+// Text returns the string the date was decoded from, or "" if it was decoded from epoch milliseconds.
+func (d Date) Text() string {
+	return d.text
+}
+
+// UnmarshalJSON decodes a date given as epoch milliseconds or as a string.
+// A string in none of the known formats does not fail the decoding of the whole entity:
+// Time is left zero and the text is kept, to be encoded back unchanged.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		parsed, err := ParseDate(value)
+		if err != nil {
+			*d = Date{text: value}
+			return nil
+		}
+		*d = parsed
+		return nil
+	}
+
+	var millis json.Number
+	if err := json.Unmarshal(data, &millis); err != nil {
+		return fmt.Errorf("unrecognised date %s", string(data))
+	}
+	f, err := millis.Float64()
+	if err != nil {
+		return err
+	}
+	*d = Date{Time: fromMillis(int64(f))}
+	return nil
+}
+
+// MarshalJSON encodes the date in the format it was decoded from.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.layout == "" && d.text != "" && d.IsZero() {
+		return json.Marshal(d.text)
+	}
+	if d.layout == "" {
+		return []byte(strconv.FormatInt(d.UnixNano()/int64(time.Millisecond), 10)), nil
+	}
+	// Keep the received text as is while the time has not been changed.
+	if original, err := time.Parse(d.layout, d.text); err == nil && original.Equal(d.Time) {
+		return json.Marshal(d.text)
+	}
+	return json.Marshal(d.Format(d.layout))
+}
+
+// fromMillis converts epoch milliseconds to a UTC time.
+func fromMillis(millis int64) time.Time {
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC()
+}
+
+// CreatedAt returns the entity's date, or the zero time if it has none.
+func (m MetaInfo) CreatedAt() time.Time {
+	if m.Date == nil {
+		return time.Time{}
+	}
+	return m.Date.Time
+}
+
+// LastWeekday returns midnight of the most recent given weekday before or on now, in now's location.
+// For example LastWeekday(time.Now(), time.Monday) is the start of "since last Monday".
+func LastWeekday(now time.Time, day time.Weekday) time.Time {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	back := (int(now.Weekday()) - int(day) + 7) % 7
+	return midnight.AddDate(0, 0, -back)
+}
+
+// SortByDate sorts the models by date, oldest first unless newestFirst; models without a date come last.
+func (m Models) SortByDate(newestFirst bool) {
+	sort.SliceStable(m.Models, func(i, j int) bool {
+		return dateBefore(m.Models[i].Meta, m.Models[j].Meta, newestFirst)
+	})
+}
+
+// CreatedSince returns the models dated at or after since.
+func (m Models) CreatedSince(since time.Time) Models {
+	return m.CreatedBetween(since, time.Time{})
+}
+
+// CreatedBetween returns the models dated at or after from and before to (a zero to means no upper bound).
+// Total is set to the number of models returned.
+func (m Models) CreatedBetween(from time.Time, to time.Time) Models {
+	var filtered Models
+	for _, item := range m.Models {
+		if dateWithin(item.Meta, from, to) {
+			filtered.Models = append(filtered.Models, item)
+		}
+	}
+	filtered.Total = len(filtered.Models)
+	return filtered
+}
+
+// SortByDate sorts the datasets by date, oldest first unless newestFirst; datasets without a date come last.
+func (d Datasets) SortByDate(newestFirst bool) {
+	sort.SliceStable(d.Datasets, func(i, j int) bool {
+		return dateBefore(d.Datasets[i].Meta, d.Datasets[j].Meta, newestFirst)
+	})
+}
+
+// CreatedSince returns the datasets dated at or after since.
+func (d Datasets) CreatedSince(since time.Time) Datasets {
+	return d.CreatedBetween(since, time.Time{})
+}
+
+// CreatedBetween returns the datasets dated at or after from and before to (a zero to means no upper bound).
+// Total is set to the number of datasets returned.
+func (d Datasets) CreatedBetween(from time.Time, to time.Time) Datasets {
+	var filtered Datasets
+	for _, item := range d.Datasets {
+		if dateWithin(item.Meta, from, to) {
+			filtered.Datasets = append(filtered.Datasets, item)
+		}
+	}
+	filtered.Total = len(filtered.Datasets)
+	return filtered
+}
+
+// dateBefore orders two entities by date, putting entities without a date last.
+func dateBefore(a MetaInfo, b MetaInfo, newestFirst bool) bool {
+	if !dated(a) || !dated(b) {
+		return dated(a) && !dated(b)
+	}
+	if newestFirst {
+		return a.Date.After(b.Date.Time)
+	}
+	return a.Date.Before(b.Date.Time)
+}
+
+// dateWithin tells whether an entity is dated within [from, to); entities without a date never are.
+func dateWithin(meta MetaInfo, from time.Time, to time.Time) bool {
+	if !dated(meta) {
+		return false
+	}
+	return !meta.Date.Before(from) && (to.IsZero() || meta.Date.Before(to))
+}
+
+// dated tells whether an entity has a date in a recognised format.
+func dated(meta MetaInfo) bool {
+	return meta.Date != nil && !meta.Date.IsZero()
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateUnmarshal(t *testing.T) {
+	want := time.Date(2020, time.December, 29, 12, 1, 54, 0, time.UTC)
+	tests := []struct {
+		data string
+		want time.Time
+	}{
+		{`{"date":1609243314000}`, want},
+		{`{"date":1.609243314e12}`, want},
+		{`{"date":"1609243314000"}`, want},
+		{`{"date":"2020-12-29T12:01:54Z"}`, want},
+		{`{"date":"2020-12-29T12:01:54.000+0000"}`, want},
+		{`{"date":"2020-12-29"}`, time.Date(2020, time.December, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		var meta MetaInfo
+		if err := json.Unmarshal([]byte(test.data), &meta); err != nil {
+			t.Errorf("Unmarshal(%s): %v", test.data, err)
+			continue
+		}
+		if meta.Date == nil || !meta.Date.Equal(test.want) {
+			t.Errorf("Unmarshal(%s) date = %v, want %v", test.data, meta.Date, test.want)
+		}
+	}
+}
+
+func TestDateUnknownFormat(t *testing.T) {
+	data := `{"date":"Dec 29, 2020 12:01:54 PM"}`
+	var meta MetaInfo
+	if err := json.Unmarshal([]byte(data), &meta); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", data, err)
+	}
+	if meta.Date == nil || !meta.Date.IsZero() || meta.Date.Text() != "Dec 29, 2020 12:01:54 PM" {
+		t.Fatalf("date = %#v, want a zero time keeping the text", meta.Date)
+	}
+	if !meta.CreatedAt().IsZero() {
+		t.Errorf("CreatedAt = %v, want the zero time", meta.CreatedAt())
+	}
+
+	encoded, err := json.Marshal(meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != data {
+		t.Errorf("Marshal = %s, want %s", encoded, data)
+	}
+
+	if err = json.Unmarshal([]byte(`{"date":{"when":"today"}}`), &meta); err == nil {
+		t.Error("no error for a date that is neither a number nor a string")
+	}
+}
+
+func TestDateMarshalKeepsFormat(t *testing.T) {
+	for _, data := range []string{
+		`{"date":1609243314000}`,
+		`{"date":"2020-12-29T12:01:54.000+0000"}`,
+		`{"date":"Tue Dec 29 12:01:54 UTC 2020"}`,
+	} {
+		var meta MetaInfo
+		if err := json.Unmarshal([]byte(data), &meta); err != nil {
+			t.Fatal(err)
+		}
+		encoded, err := json.Marshal(meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(encoded) != data {
+			t.Errorf("Marshal = %s, want %s", encoded, data)
+		}
+	}
+
+	date := NewDate(time.Date(2020, time.December, 29, 12, 1, 54, 0, time.UTC))
+	if encoded, err := json.Marshal(date); err != nil || string(encoded) != "1609243314000" {
+		t.Errorf("Marshal(NewDate) = %s, %v; want epoch milliseconds", encoded, err)
+	}
+}
+
+func TestSortByDateUndated(t *testing.T) {
+	var unknown Date
+	if err := json.Unmarshal([]byte(`"Dec 29, 2020 12:01:54 PM"`), &unknown); err != nil {
+		t.Fatal(err)
+	}
+	m := Models{Models: []Model{
+		{ID: "unknown", Meta: MetaInfo{Date: &unknown}},
+		{ID: "new", Meta: MetaInfo{Date: NewDate(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))}},
+		{ID: "none"},
+		{ID: "old", Meta: MetaInfo{Date: NewDate(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))}},
+	}}
+
+	m.SortByDate(false)
+	if m.Models[0].ID != "old" || m.Models[1].ID != "new" {
+		t.Errorf("order = %s, %s, ...; want dated models first, oldest first", m.Models[0].ID, m.Models[1].ID)
+	}
+	if since := m.CreatedSince(time.Time{}); since.Total != 2 {
+		t.Errorf("CreatedSince(zero) returned %d models, want the 2 dated ones", since.Total)
+	}
+}
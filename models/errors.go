@@ -0,0 +1,25 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// APIError is an error response of the Jaqpot API, with its ErrorReport.
+type APIError struct {
+	StatusCode int
+	Report     ErrorReport
+}
+
+// NewAPIError creates the error of a response with an unexpected status.
+func NewAPIError(statusCode int, report ErrorReport) *APIError {
+	return &APIError{StatusCode: statusCode, Report: report}
+}
+
+// Error returns the message of the ErrorReport, or the response status if it has none.
+func (e *APIError) Error() string {
+	if e.Report.Message != "" {
+		return e.Report.Message
+	}
+	return fmt.Sprintf("jaqpot: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
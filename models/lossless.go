@@ -0,0 +1,291 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Raw keeps the members of a decoded JSON object. Entities embedding it re-encode
+// unknown members as they were received, and members that were received with a zero
+// value (e.g. "visible": false) instead of dropping them because of omitempty.
+type Raw struct {
+	members map[string]json.RawMessage
+}
+
+// Has tells whether the member key (e.g. "visible") was received or explicitly set, even with a zero value.
+func (r Raw) Has(key string) bool {
+	_, ok := r.members[key]
+	return ok
+}
+
+// Member returns a member as it was received.
+func (r Raw) Member(key string) (json.RawMessage, bool) {
+	raw, ok := r.members[key]
+	return raw, ok
+}
+
+// Set marks members as set, so they are encoded even when their fields hold zero values.
+func (r *Raw) Set(keys ...string) {
+	members := r.copyMembers()
+	for _, key := range keys {
+		if _, ok := members[key]; !ok {
+			members[key] = json.RawMessage("null")
+		}
+	}
+	r.members = members
+}
+
+// Unset forgets members, so zero values are omitted again and unknown members are dropped.
+func (r *Raw) Unset(keys ...string) {
+	members := r.copyMembers()
+	for _, key := range keys {
+		delete(members, key)
+	}
+	r.members = members
+}
+
+// copyMembers copies the members, since copies of an entity share them.
+func (r *Raw) copyMembers() map[string]json.RawMessage {
+	members := make(map[string]json.RawMessage, len(r.members))
+	for key, raw := range r.members {
+		members[key] = raw
+	}
+	return members
+}
+
+// jsonFields caches the JSON member name of every field, per struct type.
+var jsonFields sync.Map
+
+// fieldNames maps the JSON member names of a struct type to field indexes.
+func fieldNames(t reflect.Type) map[string]int {
+	if names, ok := jsonFields.Load(t); ok {
+		return names.(map[string]int)
+	}
+
+	names := make(map[string]int)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Anonymous {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+				name = tagName
+			}
+		}
+		names[name] = i
+	}
+
+	jsonFields.Store(t, names)
+	return names
+}
+
+// unmarshalLossless decodes data into v, a pointer to a struct, and keeps its members in raw.
+func unmarshalLossless(data []byte, v interface{}, raw *Raw) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	raw.members = nil
+	return json.Unmarshal(data, &raw.members)
+}
+
+// marshalLossless encodes v, a struct, adding the members of raw its encoding would lose.
+func marshalLossless(v interface{}, raw Raw) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(raw.members) == 0 {
+		return data, err
+	}
+
+	var members map[string]json.RawMessage
+	if err = json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	value := reflect.ValueOf(v)
+	names := fieldNames(value.Type())
+
+	// Struct members are never omitted by omitempty; drop the empty ones that were not received.
+	for key, index := range names {
+		field := value.Field(index)
+		if _, received := raw.members[key]; !received && field.Kind() == reflect.Struct && field.IsZero() {
+			delete(members, key)
+		}
+	}
+
+	for key, member := range raw.members {
+		if _, ok := members[key]; ok {
+			continue
+		}
+		index, known := names[key]
+		if !known {
+			members[key] = member
+			continue
+		}
+		if members[key], err = json.Marshal(value.Field(index).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON decodes an algorithm, keeping the members it was received with.
+func (v *Algorithm) UnmarshalJSON(data []byte) error {
+	type plain Algorithm
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes an algorithm, including the members its fields would lose.
+func (v Algorithm) MarshalJSON() ([]byte, error) {
+	type plain Algorithm
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes an entity, keeping the members it was received with.
+func (v *JaqpotEntity) UnmarshalJSON(data []byte) error {
+	type plain JaqpotEntity
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes an entity, including the members its fields would lose.
+func (v JaqpotEntity) MarshalJSON() ([]byte, error) {
+	type plain JaqpotEntity
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes a dataset, keeping the members it was received with.
+func (v *Dataset) UnmarshalJSON(data []byte) error {
+	type plain Dataset
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes a dataset, including the members its fields would lose.
+func (v Dataset) MarshalJSON() ([]byte, error) {
+	type plain Dataset
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes meta info, keeping the members it was received with.
+func (v *MetaInfo) UnmarshalJSON(data []byte) error {
+	type plain MetaInfo
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes meta info, including the members its fields would lose.
+func (v MetaInfo) MarshalJSON() ([]byte, error) {
+	type plain MetaInfo
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes feature info, keeping the members it was received with.
+func (v *FeatureInfo) UnmarshalJSON(data []byte) error {
+	type plain FeatureInfo
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes feature info, including the members its fields would lose.
+func (v FeatureInfo) MarshalJSON() ([]byte, error) {
+	type plain FeatureInfo
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes an error report, keeping the members it was received with.
+func (v *ErrorReport) UnmarshalJSON(data []byte) error {
+	type plain ErrorReport
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes an error report, including the members its fields would lose.
+func (v ErrorReport) MarshalJSON() ([]byte, error) {
+	type plain ErrorReport
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes a feature, keeping the members it was received with.
+func (v *Feature) UnmarshalJSON(data []byte) error {
+	type plain Feature
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes a feature, including the members its fields would lose.
+func (v Feature) MarshalJSON() ([]byte, error) {
+	type plain Feature
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes a model, keeping the members it was received with.
+func (v *Model) UnmarshalJSON(data []byte) error {
+	type plain Model
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes a model, including the members its fields would lose.
+func (v Model) MarshalJSON() ([]byte, error) {
+	type plain Model
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes a BibTeX entry, keeping the members it was received with.
+func (v *BibTeX) UnmarshalJSON(data []byte) error {
+	type plain BibTeX
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes a BibTeX entry, including the members its fields would lose.
+func (v BibTeX) MarshalJSON() ([]byte, error) {
+	type plain BibTeX
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes a parameter, keeping the members it was received with.
+func (v *Parameter) UnmarshalJSON(data []byte) error {
+	type plain Parameter
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes a parameter, including the members its fields would lose.
+func (v Parameter) MarshalJSON() ([]byte, error) {
+	type plain Parameter
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes a task, keeping the members it was received with.
+func (v *Task) UnmarshalJSON(data []byte) error {
+	type plain Task
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes a task, including the members its fields would lose.
+func (v Task) MarshalJSON() ([]byte, error) {
+	type plain Task
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes a DOA, keeping the members it was received with.
+func (v *Doa) UnmarshalJSON(data []byte) error {
+	type plain Doa
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes a DOA, including the members its fields would lose.
+func (v Doa) MarshalJSON() ([]byte, error) {
+	type plain Doa
+	return marshalLossless(plain(v), v.Raw)
+}
+
+// UnmarshalJSON decodes a trained model, keeping the members it was received with.
+func (v *Trained) UnmarshalJSON(data []byte) error {
+	type plain Trained
+	return unmarshalLossless(data, (*plain)(v), &v.Raw)
+}
+
+// MarshalJSON encodes a trained model, including the members its fields would lose.
+func (v Trained) MarshalJSON() ([]byte, error) {
+	type plain Trained
+	return marshalLossless(plain(v), v.Raw)
+}
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// readFixture reads a recorded Jaqpot response from testdata.
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// assertSameJSON fails unless got and want encode the same JSON value, whatever their formatting and member order.
+func assertSameJSON(t *testing.T, got []byte, want []byte) {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("decoding %s: %v", got, err)
+	}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		t.Fatalf("decoding %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("JSON differs\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+// members decodes the top-level members of a JSON object.
+func members(t *testing.T, data []byte) map[string]json.RawMessage {
+	t.Helper()
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		fixture string
+		entity  interface{}
+	}{
+		{"model.json", &Model{}},
+		{"task.json", &Task{}},
+		{"dataset.json", &Dataset{}},
+		{"feature.json", &Feature{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			data := readFixture(t, test.fixture)
+			if err := json.Unmarshal(data, test.entity); err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := json.Marshal(test.entity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertSameJSON(t, encoded, data)
+		})
+	}
+}
+
+func TestRoundTripKeepsZeroValues(t *testing.T) {
+	tests := []struct {
+		fixture string
+		entity  interface{}
+		member  string
+		want    string
+	}{
+		{"model.json", &Model{}, "visible", "false"},
+		{"model.json", &Model{}, "onTrash", "false"},
+		{"model.json", &Model{}, "reliability", "0"},
+		{"model.json", &Model{}, "parameters", "{}"},
+		{"dataset.json", &Dataset{}, "onTrash", "false"},
+		{"dataset.json", &Dataset{}, "visible", "false"},
+		{"task.json", &Task{}, "percentageCompleted", "0"},
+		{"task.json", &Task{}, "duration", "0"},
+		{"feature.json", &Feature{}, "units", `""`},
+		{"feature.json", &Feature{}, "admissibleValues", "[]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture+"/"+test.member, func(t *testing.T) {
+			if err := json.Unmarshal(readFixture(t, test.fixture), test.entity); err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := json.Marshal(test.entity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			member, ok := members(t, encoded)[test.member]
+			if !ok {
+				t.Fatalf("%q was dropped: %s", test.member, encoded)
+			}
+			if string(member) != test.want {
+				t.Errorf("%q = %s, want %s", test.member, member, test.want)
+			}
+		})
+	}
+}
+
+func TestRoundTripKeepsUnknownMembers(t *testing.T) {
+	var model Model
+	if err := json.Unmarshal(readFixture(t, "model.json"), &model); err != nil {
+		t.Fatal(err)
+	}
+	model.Meta.Titles = []string{"Renamed"}
+	model.Visible = true
+
+	encoded, err := json.Marshal(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := members(t, encoded)
+	for member, want := range map[string]string{
+		"pretrained": "true",
+		"libraries":  `["scikit-learn"]`,
+		"type":       `"LINEAR"`,
+		"visible":    "true",
+	} {
+		if string(decoded[member]) != want {
+			t.Errorf("%q = %s, want %s", member, decoded[member], want)
+		}
+	}
+
+	meta := members(t, decoded["meta"])
+	if string(meta["titles"]) != `["Renamed"]` {
+		t.Errorf("meta titles = %s, want the changed titles", meta["titles"])
+	}
+	if string(meta["locked"]) != "false" {
+		t.Errorf("meta locked = %s, want the received false", meta["locked"])
+	}
+}
+
+func TestRawSetAndUnset(t *testing.T) {
+	var dataset Dataset
+	encoded, err := json.Marshal(dataset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := members(t, encoded)["onTrash"]; ok {
+		t.Fatalf("onTrash encoded without being set: %s", encoded)
+	}
+
+	dataset.Set("onTrash")
+	if !dataset.Has("onTrash") {
+		t.Error("Has(onTrash) = false after Set")
+	}
+	if encoded, err = json.Marshal(dataset); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(members(t, encoded)["onTrash"]); got != "false" {
+		t.Errorf("onTrash = %q after Set, want false", got)
+	}
+
+	dataset.Unset("onTrash")
+	if encoded, err = json.Marshal(dataset); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := members(t, encoded)["onTrash"]; ok {
+		t.Errorf("onTrash encoded after Unset: %s", encoded)
+	}
+}
+
+func TestRawCopiesAreIndependent(t *testing.T) {
+	var original Feature
+	if err := json.Unmarshal(readFixture(t, "feature.json"), &original); err != nil {
+		t.Fatal(err)
+	}
+	copied := original
+	copied.Unset("fromPretrained")
+
+	if !original.Has("fromPretrained") {
+		t.Error("Unset on a copy dropped the member from the original")
+	}
+	if copied.Has("fromPretrained") {
+		t.Error("Unset did not drop the member from the copy")
+	}
+}
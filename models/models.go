@@ -2,13 +2,15 @@ package models
 
 import (
 	"net/http"
-	"time"
+	"strings"
 )
 
 // ClientProperties structure
 type ClientProperties struct {
 	BaseURL    string
 	HTTPClient *http.Client
+	// AttachDOA makes predictions carry the applicability-domain assessment of every row.
+	AttachDOA bool
 }
 
 // Client structure
@@ -30,6 +32,7 @@ type Algorithm struct {
 	TrainingService    string            `json:"trainingService,omitempty"`
 	PredictionService  string            `json:"predictionService,omitempty"`
 	ReportService      string            `json:"reportService,omitempty"`
+	Raw                `json:"-"`
 }
 
 // JaqpotEntities structure
@@ -46,6 +49,7 @@ type JaqpotEntity struct {
 	Visible            bool     `json:"visible,omitempty"`
 	Temporary          bool     `json:"temporary,omitempty"`
 	Featured           bool     `json:"featured,omitempty"`
+	Raw                `json:"-"`
 }
 
 // EntryID structure
@@ -77,6 +81,13 @@ type Dataset struct {
 	TotalColumns       int           `json:"totalColumns,omitempty"`
 	SlashID            string        `json:"_id,omitempty"`
 	OnTrash            bool          `json:"onTrash,omitempty"`
+	Raw                `json:"-"`
+}
+
+// Datasets structure
+type Datasets struct {
+	Total    int
+	Datasets []Dataset
 }
 
 // MetaInfo structure
@@ -95,25 +106,14 @@ type MetaInfo struct {
 	SeeAlso      []string `json:"seeAlso,omitempty"`
 	HasSources   []string `json:"hasSources,omitempty"`
 	Doi          []string `json:"doi,omitempty"`
-	Date         string   `json:"date,omitempty"`
+	Date         *Date    `json:"date,omitempty"`
 	Picture      string   `json:"picture,omitempty"`
 	Markdown     string   `json:"markdown,omitempty"`
 	Tags         []string `json:"tags,omitempty"`
 	Read         []string `json:"read,omitempty"`
 	Write        []string `json:"write,omitempty"`
 	Execute      []string `json:"execute,omitempty"`
-}
-
-// Date structure
-type Date struct {
-	Year  int
-	Month time.Month
-	Day   int
-	Hour  int
-	Min   int
-	Sec   int
-	Nsec  int
-	loc   time.Location
+	Raw          `json:"-"`
 }
 
 // FeatureInfo structure
@@ -124,6 +124,7 @@ type FeatureInfo struct {
 	Conditions map[string]interface{} `json:"conditions,omitempty"`
 	Category   string                 `json:"category,omitempty"`
 	URI        string                 `json:"uri,omitempty"`
+	Raw        `json:"-"`
 }
 
 // ErrorReport structure
@@ -140,6 +141,7 @@ type ErrorReport struct {
 	HTTPStatus         int          `json:"httpStatus,omitempty"`
 	Trace              *ErrorReport `json:"trace,omitempty"`
 	ID                 string       `json:"id,omitempty"`
+	Raw                `json:"-"`
 }
 
 // Feature structure
@@ -154,6 +156,59 @@ type Feature struct {
 	AdmissibleValues   []string `json:"admissibleValues,omitempty"`
 	ID                 string   `json:"id,omitempty"`
 	SlashID            string   `json:"_id,omitempty"`
+	Raw                `json:"-"`
+}
+
+// Nominal tells whether the feature takes one of a fixed set of values: it has admissible values
+// or is of the NominalFeature ontological class.
+func (f Feature) Nominal() bool {
+	if len(f.AdmissibleValues) > 0 {
+		return true
+	}
+	for _, class := range f.OntologicalClasses {
+		if class == "NominalFeature" || strings.HasSuffix(class, ":NominalFeature") {
+			return true
+		}
+	}
+	return false
+}
+
+// Features structure
+type Features struct {
+	Total    int
+	Features []Feature
+}
+
+// Feature types of a FeatureSchema.
+const (
+	FeatureTypeNumeric = "NUMERIC"
+	FeatureTypeNominal = "NOMINAL"
+)
+
+// FeatureSchema structure
+type FeatureSchema struct {
+	Key              int      `json:"key"`
+	Name             string   `json:"name,omitempty"`
+	URI              string   `json:"uri,omitempty"`
+	Title            string   `json:"title,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	Units            string   `json:"units,omitempty"`
+	Type             string   `json:"type,omitempty"`
+	AdmissibleValues []string `json:"admissibleValues,omitempty"`
+	PredictorFor     string   `json:"predictorFor,omitempty"`
+}
+
+// Nominal tells whether the feature takes one of a fixed set of values.
+func (f FeatureSchema) Nominal() bool {
+	return f.Type == FeatureTypeNominal
+}
+
+// ModelSchema structure
+type ModelSchema struct {
+	ModelID string          `json:"modelId,omitempty"`
+	Title   string          `json:"title,omitempty"`
+	Inputs  []FeatureSchema `json:"inputs,omitempty"`
+	Outputs []FeatureSchema `json:"outputs,omitempty"`
 }
 
 // Model structure
@@ -181,6 +236,7 @@ type Model struct {
 	ID                   string                 `json:"id,omitempty"`
 	SlashID              string                 `json:"_id,omitempty"`
 	OnTrash              bool                   `json:"onTrash,omitempty"`
+	Raw                  `json:"-"`
 }
 
 // BibTeX structure
@@ -216,6 +272,7 @@ type BibTeX struct {
 	Publisher          string   `json:"publisher,omitempty"`
 	ID                 string   `json:"id,omitempty"`
 	Abstract           string   `json:"abstract,omitempty"`
+	Raw                `json:"-"`
 }
 
 // Models structure
@@ -235,6 +292,7 @@ type Parameter struct {
 	MinArraySize  int           `json:"minArraySize,omitempty"`
 	MaxArraySize  int           `json:"maxArraySize,omitempty"`
 	Description   string        `json:"description,omitempty"`
+	Raw           `json:"-"`
 }
 
 // Prediction structure
@@ -243,6 +301,53 @@ type Prediction struct {
 	DatasetID   string                   `json:"datasetId,omitempty"`
 	Data        []map[string]interface{} `json:"data,omitempty"`
 	Predictions []map[string]interface{} `json:"predictions,omitempty"`
+	Domain      []DomainAssessment       `json:"domain,omitempty"`
+}
+
+// DomainAssessment structure
+type DomainAssessment struct {
+	Leverage float64 `json:"leverage"`
+	InDomain bool    `json:"inDomain"`
+}
+
+// ModelPrediction structure
+type ModelPrediction struct {
+	ModelID    string     `json:"modelId,omitempty"`
+	Prediction Prediction `json:"prediction,omitempty"`
+	OutsideDOA []bool     `json:"outsideDoa,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// Consensus structure
+type Consensus struct {
+	Mean      *float64 `json:"mean,omitempty"`
+	Median    *float64 `json:"median,omitempty"`
+	Majority  string   `json:"majority,omitempty"`
+	Agreement float64  `json:"agreement,omitempty"`
+	Votes     int      `json:"votes,omitempty"`
+}
+
+// MultiPrediction structure
+type MultiPrediction struct {
+	Results   []ModelPrediction `json:"results,omitempty"`
+	Consensus []Consensus       `json:"consensus,omitempty"`
+}
+
+// WilliamsPoint structure
+type WilliamsPoint struct {
+	Row                  int     `json:"row"`
+	Observed             float64 `json:"observed"`
+	Predicted            float64 `json:"predicted"`
+	Leverage             float64 `json:"leverage"`
+	StandardisedResidual float64 `json:"standardisedResidual"`
+	Class                string  `json:"class,omitempty"`
+}
+
+// WilliamsPlot structure
+type WilliamsPlot struct {
+	Threshold     float64         `json:"threshold"`
+	ResidualLimit float64         `json:"residualLimit"`
+	Points        []WilliamsPoint `json:"points,omitempty"`
 }
 
 // Substance structure
@@ -263,12 +368,31 @@ type Task struct {
 	SlashID             string      `json:"_id,omitempty"`
 	ResultURI           string      `json:"resultUri,omitempty"`
 	Result              string      `json:"result,omitempty"`
-	HasStatus           string      `json:"hasStatus,omitempty"`
+	HasStatus           TaskStatus  `json:"hasStatus,omitempty"`
 	PercentageCompleted float32     `json:"percentageCompleted,omitempty"`
 	ErrorReport         ErrorReport `json:"errorReport,omitempty"`
 	HTTPStatus          int         `json:"httpStatus,omitempty"`
 	Duration            float64     `json:"duration,omitempty"`
 	Type                string      `json:"type,omitempty"`
+	Raw                 `json:"-"`
+}
+
+// Tasks structure
+type Tasks struct {
+	Total int
+	Tasks []Task
+}
+
+// Filter returns the tasks with the given status and type (empty values match any).
+func (t Tasks) Filter(status TaskStatus, taskType string) Tasks {
+	var filtered Tasks
+	for _, item := range t.Tasks {
+		if (status == "" || item.HasStatus == status) && (taskType == "" || item.Type == taskType) {
+			filtered.Tasks = append(filtered.Tasks, item)
+		}
+	}
+	filtered.Total = len(filtered.Tasks)
+	return filtered
 }
 
 // Trained structure
@@ -285,6 +409,7 @@ type Trained struct {
 	Description         []string    `json:"description,omitempty"`
 	Algorithm           []string    `json:"algorithm,omitempty"`
 	Batched             bool        `json:"batched,omitempty"`
+	Raw                 `json:"-"`
 }
 
 // Doa structure
@@ -293,4 +418,13 @@ type Doa struct {
 	ModelID   string      `json:"modelId,omitempty"`
 	DoaMatrix [][]float32 `json:"doaMatrix,omitempty"`
 	AValue    float32     `json:"aValue,omitempty"`
+	ID        string      `json:"id,omitempty"`
+	SlashID   string      `json:"_id,omitempty"`
+	Raw       `json:"-"`
+}
+
+// Doas structure
+type Doas struct {
+	Total int
+	Doas  []Doa
 }
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskStatus is the status of a Jaqpot task.
+type TaskStatus string
+
+// Statuses of a task.
+const (
+	TaskQueued    TaskStatus = "QUEUED"
+	TaskRunning   TaskStatus = "RUNNING"
+	TaskCompleted TaskStatus = "COMPLETED"
+	TaskError     TaskStatus = "ERROR"
+	TaskCancelled TaskStatus = "CANCELLED"
+	TaskRejected  TaskStatus = "REJECTED"
+)
+
+// taskTransitions lists the statuses a task may move to from each non-terminal status.
+var taskTransitions = map[TaskStatus][]TaskStatus{
+	TaskQueued:  {TaskRunning, TaskCompleted, TaskError, TaskCancelled, TaskRejected},
+	TaskRunning: {TaskCompleted, TaskError, TaskCancelled},
+}
+
+// ParseTaskStatus parses a task status, ignoring case ("CANCELED" is accepted too).
+func ParseTaskStatus(value string) (TaskStatus, error) {
+	status := TaskStatus(strings.ToUpper(strings.TrimSpace(value)))
+	if status == "CANCELED" {
+		status = TaskCancelled
+	}
+	switch status {
+	case TaskQueued, TaskRunning, TaskCompleted, TaskError, TaskCancelled, TaskRejected:
+		return status, nil
+	}
+	return status, fmt.Errorf("unknown task status %q", value)
+}
+
+// IsTerminal tells whether a task with this status has finished, successfully or not.
+func (s TaskStatus) IsTerminal() bool {
+	switch s {
+	case TaskCompleted, TaskError, TaskCancelled, TaskRejected:
+		return true
+	}
+	return false
+}
+
+// IsSuccess tells whether a task with this status has completed successfully.
+func (s TaskStatus) IsSuccess() bool {
+	return s == TaskCompleted
+}
+
+// CanTransitionTo tells whether a task may move from this status to next.
+// Staying in the same status is always allowed; terminal statuses allow nothing else.
+func (s TaskStatus) CanTransitionTo(next TaskStatus) bool {
+	if s == next {
+		return true
+	}
+	for _, allowed := range taskTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+package gojaqpot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/euclia/gojaqpot/dataset"
+	"github.com/euclia/gojaqpot/feature"
+	"github.com/euclia/gojaqpot/model"
+	"github.com/euclia/gojaqpot/models"
+	"github.com/euclia/gojaqpot/task"
+)
+
+// defaultConcurrency is the number of models PredictMany works on at once when none is given.
+const defaultConcurrency = 4
+
+// PredictManyOptions configures PredictMany.
+type PredictManyOptions struct {
+	// Concurrency bounds the number of models predicted at once (defaults to 4).
+	Concurrency int
+
+	// Consensus computes per-row consensus outputs: mean and median when every model is a
+	// regression model, majority vote otherwise. A model is a classifier when its predicted
+	// feature is nominal (see models.Feature.Nominal).
+	Consensus bool
+
+	// CheckDOA fetches each model's DOA and flags rows falling outside it.
+	// The assessment is attached to the model's prediction as well.
+	CheckDOA bool
+
+	// ExcludeOutsideDOA leaves rows flagged outside a model's DOA out of the consensus (requires CheckDOA).
+	ExcludeOutsideDOA bool
+
+	// Watcher polls the prediction tasks, e.g. to share one schedule with other work; it must have been
+	// created with the same AuthToken. By default PredictMany polls its tasks with a watcher of its own.
+	Watcher *task.Watcher
+}
+
+// upload is a dataset shared by models with the same independent features.
+type upload struct {
+	once      sync.Once
+	datasetID string
+	err       error
+}
+
+// PredictMany is a method to make the same prediction with several models concurrently.
+// The input is uploaded once for every group of models sharing the same independent features,
+// and the prediction tasks are polled together by one task.Watcher.
+// A model that fails is reported in its result; an error is returned only if no model succeeded.
+func (client *Client) PredictMany(modelIDs []string, values []map[string]interface{}, AuthToken string, opts PredictManyOptions) (multi models.MultiPrediction, err error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	watcher := opts.Watcher
+	if watcher == nil {
+		watcher = client.NewTaskWatcher(AuthToken, task.WatcherOptions{})
+		defer watcher.Stop()
+	}
+
+	multi.Results = make([]models.ModelPrediction, len(modelIDs))
+	nominal := make([]bool, len(modelIDs))
+	uploads := make(map[string]*upload)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for i, modelID := range modelIDs {
+		wg.Add(1)
+		go func(i int, modelID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result := &multi.Results[i]
+			result.ModelID = modelID
+
+			currentModel, internalError := model.GetModel(modelID, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+			if internalError != nil {
+				result.Error = internalError.Error()
+				return
+			}
+
+			if opts.Consensus {
+				nominal[i] = client.predictsNominal(currentModel, AuthToken)
+			}
+
+			signature := append([]string(nil), currentModel.IndependentFeatures...)
+			sort.Strings(signature)
+			key := strings.Join(signature, "\n")
+
+			mu.Lock()
+			shared, ok := uploads[key]
+			if !ok {
+				shared = &upload{}
+				uploads[key] = shared
+			}
+			mu.Unlock()
+
+			shared.once.Do(func() {
+				var jaqDataset models.Dataset
+				jaqDataset, shared.err = dataset.CreateDataset(modelID, values, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+				if shared.err == nil {
+					shared.datasetID, shared.err = dataset.PostDataset(jaqDataset, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+				}
+			})
+			if shared.err != nil {
+				result.Error = shared.err.Error()
+				return
+			}
+
+			result.Prediction, internalError = client.predictDataset(context.Background(), modelID, shared.datasetID, nil, watcher, AuthToken)
+			if internalError != nil {
+				result.Error = internalError.Error()
+				return
+			}
+
+			if opts.CheckDOA {
+				if result.Prediction.Domain == nil {
+					_ = client.attachDOA(&result.Prediction, AuthToken)
+				}
+				for _, assessment := range result.Prediction.Domain {
+					result.OutsideDOA = append(result.OutsideDOA, !assessment.InDomain)
+				}
+			}
+		}(i, modelID)
+	}
+	wg.Wait()
+
+	var failures []string
+	for _, result := range multi.Results {
+		if result.Error != "" {
+			failures = append(failures, result.ModelID+": "+result.Error)
+		}
+	}
+	if len(modelIDs) > 0 && len(failures) == len(modelIDs) {
+		return multi, errors.New("all predictions failed: " + strings.Join(failures, "; "))
+	}
+
+	if opts.Consensus {
+		multi.Consensus = consensus(multi.Results, nominal, len(values), opts.ExcludeOutsideDOA)
+	}
+	return multi, nil
+}
+
+// predictsNominal tells whether a model is a classifier, i.e. one of its predicted features is nominal.
+// A model whose predicted features cannot be fetched is taken for a regression model.
+func (client *Client) predictsNominal(currentModel models.Model, AuthToken string) bool {
+	feats, err := feature.GetFeatures(currentModel.PredictedFeatures, AuthToken, client.C.BaseURL, client.C.HTTPClient)
+	if err != nil {
+		return false
+	}
+	for _, feat := range feats {
+		if feat.Nominal() {
+			return true
+		}
+	}
+	return false
+}
+
+// consensus aggregates the per-row predictions of every successful model; nominal tells which models are classifiers.
+// Rows are averaged only when every model voting on them is a regression model with a numeric prediction.
+func consensus(results []models.ModelPrediction, nominal []bool, rows int, excludeOutside bool) []models.Consensus {
+	agg := make([]models.Consensus, rows)
+
+	for row := 0; row < rows; row++ {
+		var numbers []float64
+		var labels []string
+
+		for i, result := range results {
+			if result.Error != "" || row >= len(result.Prediction.Predictions) {
+				continue
+			}
+			if excludeOutside && row < len(result.OutsideDOA) && result.OutsideDOA[row] {
+				continue
+			}
+
+			value, ok := firstValue(result.Prediction.Predictions[row])
+			if !ok {
+				continue
+			}
+			labels = append(labels, fmt.Sprintf("%v", value))
+			if nominal[i] {
+				continue
+			}
+			if f, err := toFloat(value); err == nil {
+				numbers = append(numbers, f)
+			}
+		}
+
+		agg[row].Votes = len(labels)
+		if len(labels) == 0 {
+			continue
+		}
+
+		if len(numbers) == len(labels) {
+			sort.Float64s(numbers)
+			var sum float64
+			for _, f := range numbers {
+				sum += f
+			}
+			mean := sum / float64(len(numbers))
+			median := numbers[len(numbers)/2]
+			if len(numbers)%2 == 0 {
+				median = (numbers[len(numbers)/2-1] + numbers[len(numbers)/2]) / 2
+			}
+			agg[row].Mean = &mean
+			agg[row].Median = &median
+			continue
+		}
+
+		counts := make(map[string]int)
+		for _, label := range labels {
+			counts[label]++
+		}
+		for label, count := range counts {
+			if count > counts[agg[row].Majority] || (count == counts[agg[row].Majority] && label < agg[row].Majority) {
+				agg[row].Majority = label
+			}
+		}
+		agg[row].Agreement = float64(counts[agg[row].Majority]) / float64(len(labels))
+	}
+	return agg
+}
+
+// firstValue returns the value of a prediction row, using the first endpoint in key order if there are several.
+func firstValue(pred map[string]interface{}) (interface{}, bool) {
+	keys := make([]string, 0, len(pred))
+	for key := range pred {
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, false
+	}
+	sort.Strings(keys)
+	return pred[keys[0]], true
+}
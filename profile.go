@@ -0,0 +1,39 @@
+package gojaqpot
+
+import (
+	"github.com/euclia/gojaqpot/config"
+)
+
+// NewClient creates a Jaqpot Go Client for a profile, with its base URL, service prefix, timeout and proxy.
+func NewClient(profile config.Profile) (client *Client, err error) {
+	client = InitClient(profile.NormalizedBaseURL())
+
+	defaultTimeout := client.C.HTTPClient.Timeout
+	client.C.HTTPClient, err = profile.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	if profile.Timeout == 0 {
+		client.C.HTTPClient.Timeout = defaultTimeout
+	}
+	return client, nil
+}
+
+// NewClientFromProfile creates a Jaqpot Go Client from a named profile of the default config file
+// (an empty name selects the current profile), and returns the profile's auth token.
+func NewClientFromProfile(name string) (client *Client, AuthToken string, err error) {
+	file, err := config.Load(config.DefaultPath())
+	if err != nil {
+		return nil, "", err
+	}
+	profile, err := file.Profile(name)
+	if err != nil {
+		return nil, "", err
+	}
+	AuthToken, err = profile.ResolveToken()
+	if err != nil {
+		return nil, "", err
+	}
+	client, err = NewClient(profile)
+	return client, AuthToken, err
+}
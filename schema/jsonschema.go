@@ -0,0 +1,161 @@
+package schema
+
+import (
+	"regexp"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+// draft is the JSON Schema dialect of the generated schemas.
+const draft = "http://json-schema.org/draft-07/schema#"
+
+// Document is a generated JSON Schema or OpenAPI document, ready to be marshalled as JSON.
+type Document map[string]interface{}
+
+// invalidComponentChars matches the characters OpenAPI does not allow in component names.
+var invalidComponentChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// RowJSONSchema generates the JSON Schema of one row of prediction input for the model.
+func RowJSONSchema(modelSchema models.ModelSchema) Document {
+	row := objectSchema(modelSchema.Inputs, false)
+	row["$schema"] = draft
+	row["title"] = title(modelSchema) + " input row"
+	return row
+}
+
+// BatchJSONSchema generates the JSON Schema of a batch (array) of prediction input rows for the model.
+func BatchJSONSchema(modelSchema models.ModelSchema) Document {
+	return Document{
+		"$schema":  draft,
+		"title":    title(modelSchema) + " input batch",
+		"type":     "array",
+		"minItems": 1,
+		"items":    objectSchema(modelSchema.Inputs, false),
+	}
+}
+
+// OpenAPIComponents generates the OpenAPI 3 components describing a prediction request for the model
+// (a batch of input rows) and its response (a models.Prediction).
+func OpenAPIComponents(modelSchema models.ModelSchema) Document {
+	prefix := ComponentPrefix(modelSchema)
+	ref := func(name string) Document {
+		return Document{"$ref": "#/components/schemas/" + prefix + name}
+	}
+
+	return Document{
+		"schemas": Document{
+			prefix + "Input":  objectSchema(modelSchema.Inputs, false),
+			prefix + "Output": objectSchema(modelSchema.Outputs, true),
+			prefix + "PredictionRequest": Document{
+				"type":     "array",
+				"minItems": 1,
+				"items":    ref("Input"),
+			},
+			prefix + "PredictionResponse": Document{
+				"type": "object",
+				"properties": Document{
+					"modelId":     Document{"type": "string"},
+					"datasetId":   Document{"type": "string"},
+					"data":        Document{"type": "array", "items": ref("Input")},
+					"predictions": Document{"type": "array", "items": ref("Output")},
+					"domain": Document{
+						"type": "array",
+						"items": Document{
+							"type": "object",
+							"properties": Document{
+								"leverage": Document{"type": "number"},
+								"inDomain": Document{"type": "boolean"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"requestBodies": Document{
+			prefix + "Prediction": Document{
+				"required": true,
+				"content": Document{
+					"application/json": Document{"schema": ref("PredictionRequest")},
+				},
+			},
+		},
+		"responses": Document{
+			prefix + "Prediction": Document{
+				"description": "Predictions of " + title(modelSchema),
+				"content": Document{
+					"application/json": Document{"schema": ref("PredictionResponse")},
+				},
+			},
+		},
+	}
+}
+
+// ComponentPrefix returns the prefix of the OpenAPI component names generated for the model.
+func ComponentPrefix(modelSchema models.ModelSchema) string {
+	return "Model_" + invalidComponentChars.ReplaceAllString(modelSchema.ModelID, "_") + "_"
+}
+
+// objectSchema describes an object holding one property per feature.
+// Output objects allow missing properties, since a prediction need not carry every output.
+func objectSchema(features []models.FeatureSchema, output bool) Document {
+	properties := Document{}
+	required := []string{}
+
+	for _, feat := range features {
+		properties[feat.Name] = propertySchema(feat)
+		required = append(required, feat.Name)
+	}
+
+	object := Document{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if !output {
+		object["required"] = required
+	}
+	return object
+}
+
+// propertySchema describes the value of a single feature.
+func propertySchema(feat models.FeatureSchema) Document {
+	property := Document{}
+	switch {
+	case feat.Nominal() && len(feat.AdmissibleValues) == 0:
+		// A nominal feature need not list its values; an empty enum would admit none.
+		property["type"] = "string"
+	case feat.Nominal():
+		enum := make([]interface{}, len(feat.AdmissibleValues))
+		for i, value := range feat.AdmissibleValues {
+			enum[i] = value
+		}
+		property["enum"] = enum
+	default:
+		property["type"] = "number"
+	}
+
+	if feat.Title != "" && feat.Title != feat.Name {
+		property["title"] = feat.Title
+	}
+	if feat.Description != "" {
+		property["description"] = feat.Description
+	}
+	if feat.Units != "" {
+		property["x-units"] = feat.Units
+	}
+	if feat.PredictorFor != "" {
+		property["x-predictorFor"] = feat.PredictorFor
+	}
+	if feat.URI != "" {
+		property["x-featureUri"] = feat.URI
+	}
+	return property
+}
+
+// title names the model in generated documents.
+func title(modelSchema models.ModelSchema) string {
+	if modelSchema.Title != "" {
+		return modelSchema.Title
+	}
+	return "Model " + modelSchema.ModelID
+}
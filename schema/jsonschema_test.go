@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+func TestRowJSONSchemaProperties(t *testing.T) {
+	currentModel := models.Model{
+		SlashID:             "m1",
+		IndependentFeatures: []string{"feature/mw", "feature/class", "feature/label"},
+	}
+	features := map[string]models.Feature{
+		"feature/mw":    {Meta: models.MetaInfo{Titles: []string{"MW"}}, Units: "g/mol"},
+		"feature/class": {Meta: models.MetaInfo{Titles: []string{"Class"}}, AdmissibleValues: []string{"active", "inactive"}},
+		"feature/label": {Meta: models.MetaInfo{Titles: []string{"Label"}}, OntologicalClasses: []string{"ot:NominalFeature"}},
+	}
+
+	properties := RowJSONSchema(Resolve(currentModel, features))["properties"].(Document)
+
+	want := map[string]Document{
+		"MW":    {"type": "number", "x-units": "g/mol", "x-featureUri": "feature/mw"},
+		"Class": {"enum": []interface{}{"active", "inactive"}, "x-featureUri": "feature/class"},
+		"Label": {"type": "string", "x-featureUri": "feature/label"},
+	}
+	for name, property := range want {
+		if got := properties[name]; !reflect.DeepEqual(got, property) {
+			t.Errorf("property %s = %#v, want %#v", name, got, property)
+		}
+	}
+}
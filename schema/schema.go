@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"net/http"
+
+	"github.com/euclia/gojaqpot/feature"
+	"github.com/euclia/gojaqpot/model"
+	"github.com/euclia/gojaqpot/models"
+)
+
+// GetModelSchema is a method to describe a model's inputs and outputs by resolving its features.
+func GetModelSchema(modelID string, AuthToken string, BaseURL string, HTTPClient *http.Client) (modelSchema models.ModelSchema, err error) {
+	currentModel, err := model.GetModel(modelID, AuthToken, BaseURL, HTTPClient)
+	if err != nil {
+		return modelSchema, err
+	}
+
+	uris := append(append([]string(nil), currentModel.IndependentFeatures...), currentModel.PredictedFeatures...)
+	feats, err := feature.GetFeatures(uris, AuthToken, BaseURL, HTTPClient)
+	if err != nil {
+		return modelSchema, err
+	}
+
+	resolved := make(map[string]models.Feature, len(uris))
+	for i, uri := range uris {
+		resolved[uri] = feats[i]
+	}
+
+	modelSchema = Resolve(currentModel, resolved)
+	if modelSchema.ModelID == "" {
+		modelSchema.ModelID = modelID
+	}
+	return modelSchema, nil
+}
+
+// Resolve builds a model's schema from its feature definitions, keyed by feature URI.
+// Inputs follow the order of Model.IndependentFeatures, outputs that of Model.PredictedFeatures.
+func Resolve(currentModel models.Model, features map[string]models.Feature) (modelSchema models.ModelSchema) {
+	modelSchema.ModelID = currentModel.ID
+	if modelSchema.ModelID == "" {
+		modelSchema.ModelID = currentModel.SlashID
+	}
+	if len(currentModel.Meta.Titles) > 0 {
+		modelSchema.Title = currentModel.Meta.Titles[0]
+	}
+
+	// Without usable additional info, features are named after their titles.
+	info, _ := currentModel.DecodeAdditionalInfo()
+	modelSchema.Inputs = describe(currentModel.IndependentFeatures, info.IndependentFeatures, features)
+	modelSchema.Outputs = describe(currentModel.PredictedFeatures, info.PredictedFeatures, features)
+	return modelSchema
+}
+
+// describe builds the schema of each feature URI, named after names or, failing that, the feature's title.
+func describe(uris []string, names map[string]string, features map[string]models.Feature) []models.FeatureSchema {
+	described := make([]models.FeatureSchema, len(uris))
+	for key, uri := range uris {
+		feat := features[uri]
+		featSchema := models.FeatureSchema{
+			Key:              key,
+			Name:             names[uri],
+			URI:              uri,
+			Units:            feat.Units,
+			Type:             models.FeatureTypeNumeric,
+			AdmissibleValues: feat.AdmissibleValues,
+			PredictorFor:     feat.PredictorFor,
+		}
+		if len(feat.Meta.Titles) > 0 {
+			featSchema.Title = feat.Meta.Titles[0]
+		}
+		if len(feat.Meta.Descriptions) > 0 {
+			featSchema.Description = feat.Meta.Descriptions[0]
+		}
+		if featSchema.Name == "" {
+			featSchema.Name = featSchema.Title
+		}
+		if feat.Nominal() {
+			featSchema.Type = models.FeatureTypeNominal
+		}
+		described[key] = featSchema
+	}
+	return described
+}
@@ -2,9 +2,9 @@ package task
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/euclia/gojaqpot/models"
 )
@@ -27,7 +27,6 @@ func GetTask(taskID string, AuthToken string, BaseURL string, HTTPClient *http.C
 	var returnTask models.Task
 
 	if err != nil {
-		fmt.Printf(err.Error())
 		return returnTask, err
 	}
 
@@ -35,7 +34,7 @@ func GetTask(taskID string, AuthToken string, BaseURL string, HTTPClient *http.C
 		var errorReport models.ErrorReport
 		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
 		defer resp.Body.Close()
-		err = errors.New(errorReport.Message)
+		err = models.NewAPIError(resp.StatusCode, errorReport)
 		return returnTask, err
 	}
 	defer resp.Body.Close()
@@ -43,3 +42,105 @@ func GetTask(taskID string, AuthToken string, BaseURL string, HTTPClient *http.C
 	err = json.NewDecoder(resp.Body).Decode(&returnTask)
 	return returnTask, err
 }
+
+// GetMyTasks is a method to get a list of user's tasks, optionally filtered by status and type (empty values are ignored).
+// Total is the number of the user's tasks with the status, as counted by the server, unless a type is given:
+// the type is filtered on the returned page only, and Total is then the number of tasks returned.
+func GetMyTasks(status models.TaskStatus, taskType string, min int, max int, AuthToken string, BaseURL string, HTTPClient *http.Client) (myTasks models.Tasks, err error) {
+	var endpoint = BaseURL + taskPath
+	req, err := http.NewRequest("GET", endpoint, nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+AuthToken)
+
+	q := req.URL.Query()
+	if status != "" {
+		q.Add("status", string(status))
+	}
+	q.Add("min", strconv.Itoa(min))
+	q.Add("max", strconv.Itoa(max))
+
+	req.URL.RawQuery = q.Encode()
+	resp, err := HTTPClient.Do(req)
+	var returnTasks models.Tasks
+
+	if err != nil {
+		return returnTasks, err
+	}
+
+	if resp.StatusCode >= 300 {
+		var errorReport models.ErrorReport
+		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
+		defer resp.Body.Close()
+		err = models.NewAPIError(resp.StatusCode, errorReport)
+		return returnTasks, err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&returnTasks.Tasks)
+
+	returnTasks.Total, _ = strconv.Atoi(resp.Header.Get("Total"))
+
+	// The server filters by status only; filter again so both filters always apply.
+	// The server's Total does not know of the type filter, so it no longer holds once that is applied.
+	filtered := returnTasks.Filter(status, taskType)
+	returnTasks.Tasks = filtered.Tasks
+	if taskType != "" {
+		returnTasks.Total = filtered.Total
+	}
+
+	return returnTasks, err
+}
+
+// CancelTask is a method to cancel a running task. It returns the task as left by the cancellation.
+func CancelTask(taskID string, AuthToken string, BaseURL string, HTTPClient *http.Client) (retTask models.Task, err error) {
+	if _, err = changeTask("PUT", taskID+"/cancel", AuthToken, BaseURL, HTTPClient); err != nil {
+		return retTask, err
+	}
+	return GetTask(taskID, AuthToken, BaseURL, HTTPClient)
+}
+
+// DeleteTask is a method to delete a finished task. It returns the task as it was before deletion.
+func DeleteTask(taskID string, AuthToken string, BaseURL string, HTTPClient *http.Client) (retTask models.Task, err error) {
+	returnTask, err := GetTask(taskID, AuthToken, BaseURL, HTTPClient)
+	if err != nil {
+		return returnTask, err
+	}
+
+	_, err = changeTask("DELETE", taskID, AuthToken, BaseURL, HTTPClient)
+	return returnTask, err
+}
+
+// changeTask sends a request changing a task and returns the task the server answers with, if any.
+func changeTask(method string, path string, AuthToken string, BaseURL string, HTTPClient *http.Client) (retTask models.Task, err error) {
+	var endpoint = BaseURL + taskPath + path
+
+	req, err := http.NewRequest(method, endpoint, nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+AuthToken)
+
+	resp, err := HTTPClient.Do(req)
+
+	var returnTask models.Task
+
+	if err != nil {
+		return returnTask, err
+	}
+
+	if resp.StatusCode >= 300 {
+		var errorReport models.ErrorReport
+		_ = json.NewDecoder(resp.Body).Decode(&errorReport)
+		defer resp.Body.Close()
+		err = models.NewAPIError(resp.StatusCode, errorReport)
+		return returnTask, err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&returnTask)
+	if err == io.EOF {
+		// Some operations answer without a body.
+		err = nil
+	}
+	return returnTask, err
+}
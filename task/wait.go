@@ -0,0 +1,118 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+const (
+	// pollInterval is the time between two polls of a task.
+	pollInterval = 1 * time.Second
+
+	// maxPollErrors is the number of consecutive failed polls after which waiting gives up.
+	maxPollErrors = 3
+
+	// maxBadTransitions is the number of unexpected status changes after which waiting gives up.
+	maxBadTransitions = 3
+)
+
+// ErrTaskStalled is returned when a task keeps reporting unexpected status changes while polled.
+var ErrTaskStalled = errors.New("task reports inconsistent status changes")
+
+// Wait is a method to poll a task until it finishes. onUpdate, if not nil, is called with every polled task.
+// A task that ends in ERROR, CANCELLED or REJECTED is returned together with an error from its ErrorReport.
+func Wait(taskID string, AuthToken string, BaseURL string, HTTPClient *http.Client, onUpdate func(models.Task)) (retTask models.Task, err error) {
+	return WaitContext(context.Background(), taskID, AuthToken, BaseURL, HTTPClient, onUpdate)
+}
+
+// WaitContext is a method to poll a task until it finishes, like Wait, or until ctx is done.
+// When ctx is done polling stops and ctx.Err() is returned; the task itself keeps running.
+func WaitContext(ctx context.Context, taskID string, AuthToken string, BaseURL string, HTTPClient *http.Client, onUpdate func(models.Task)) (retTask models.Task, err error) {
+	var previous models.TaskStatus
+	var pollErrors, badTransitions int
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return retTask, err
+		}
+		current, err := GetTask(taskID, AuthToken, BaseURL, HTTPClient)
+		if err != nil {
+			pollErrors++
+			if pollErrors >= maxPollErrors {
+				return retTask, err
+			}
+			if err := sleep(ctx, pollInterval); err != nil {
+				return retTask, err
+			}
+			continue
+		}
+		pollErrors = 0
+		retTask = current
+
+		if onUpdate != nil {
+			onUpdate(current)
+		}
+
+		status, err := CheckTransition(previous, current)
+		if err != nil {
+			badTransitions++
+			log.Printf("gojaqpot: task %s: %s", taskID, err.Error())
+			if badTransitions >= maxBadTransitions {
+				return retTask, fmt.Errorf("task %s: %w", taskID, ErrTaskStalled)
+			}
+		} else {
+			previous = status
+		}
+
+		if status.IsTerminal() || (current.PercentageCompleted == 100 && current.Result != "" && status != models.TaskError) {
+			return retTask, Outcome(current)
+		}
+
+		if err := sleep(ctx, pollInterval); err != nil {
+			return retTask, err
+		}
+	}
+}
+
+// sleep waits for d, or returns ctx.Err() if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// CheckTransition parses the status of a polled task and checks it is a valid move from previous
+// (an empty previous status accepts any status).
+func CheckTransition(previous models.TaskStatus, current models.Task) (status models.TaskStatus, err error) {
+	status, err = models.ParseTaskStatus(string(current.HasStatus))
+	if err != nil {
+		return status, err
+	}
+	if previous != "" && !previous.CanTransitionTo(status) {
+		return status, fmt.Errorf("unexpected status change from %s to %s", previous, status)
+	}
+	return status, nil
+}
+
+// Outcome returns nil for a successfully finished task, or an error built from the task's ErrorReport.
+func Outcome(finished models.Task) error {
+	status, _ := models.ParseTaskStatus(string(finished.HasStatus))
+	if status.IsSuccess() || (status != models.TaskError && finished.PercentageCompleted == 100 && finished.Result != "") {
+		return nil
+	}
+	if finished.ErrorReport.Message != "" {
+		return errors.New(finished.ErrorReport.Message)
+	}
+	return fmt.Errorf("task %s ended with status %s", finished.SlashID, finished.HasStatus)
+}
@@ -0,0 +1,300 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+// Event is a change of a watched task: its status or its progress.
+type Event struct {
+	TaskID   string
+	Task     models.Task
+	Previous models.TaskStatus
+	// Done tells that the task is no longer watched: it finished or could not be polled.
+	Done bool
+	// Err is set when the task could not be polled or did not finish successfully.
+	Err error
+}
+
+// WatcherOptions configures a Watcher.
+type WatcherOptions struct {
+	// MinInterval is the poll interval with a single task in flight (defaults to 1s).
+	MinInterval time.Duration
+	// MaxInterval caps the poll interval however many tasks are in flight (defaults to 30s).
+	MaxInterval time.Duration
+	// BatchSize is the number of tasks polled at once; the interval grows by MinInterval
+	// for every full batch in flight (defaults to 8).
+	BatchSize int
+	// OnEvent, if set, receives every event instead of the Events channel.
+	OnEvent func(Event)
+	// Buffer is the capacity of the Events channel (defaults to 64).
+	Buffer int
+}
+
+// watched is the state of a task followed by a Watcher.
+type watched struct {
+	task           models.Task
+	status         models.TaskStatus
+	pollErrors     int
+	badTransitions int
+	waiters        []chan Event
+}
+
+// Watcher polls many tasks on one shared schedule and publishes their changes.
+type Watcher struct {
+	authToken  string
+	baseURL    string
+	httpClient *http.Client
+	opts       WatcherOptions
+
+	mu         sync.Mutex
+	tasks      map[string]*watched
+	events     chan Event
+	subscribed bool
+	stop       chan struct{}
+	once       sync.Once
+}
+
+// NewWatcher creates a Watcher and starts its poll loop; call Stop to end it.
+func NewWatcher(AuthToken string, BaseURL string, HTTPClient *http.Client, opts WatcherOptions) *Watcher {
+	if opts.MinInterval <= 0 {
+		opts.MinInterval = pollInterval
+	}
+	if opts.MaxInterval < opts.MinInterval {
+		opts.MaxInterval = 30 * time.Second
+		if opts.MaxInterval < opts.MinInterval {
+			opts.MaxInterval = opts.MinInterval
+		}
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 8
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = 64
+	}
+
+	w := &Watcher{
+		authToken:  AuthToken,
+		baseURL:    BaseURL,
+		httpClient: HTTPClient,
+		opts:       opts,
+		tasks:      make(map[string]*watched),
+		events:     make(chan Event, opts.Buffer),
+		stop:       make(chan struct{}),
+	}
+	go w.loop()
+	return w
+}
+
+// Watch starts watching tasks; tasks already watched are left as they are.
+func (w *Watcher) Watch(taskIDs ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range taskIDs {
+		if _, ok := w.tasks[id]; !ok {
+			w.tasks[id] = &watched{}
+		}
+	}
+}
+
+// Await watches a task and returns a channel receiving its final event.
+func (w *Watcher) Await(taskID string) <-chan Event {
+	done := make(chan Event, 1)
+	w.mu.Lock()
+	state, ok := w.tasks[taskID]
+	if !ok {
+		state = &watched{}
+		w.tasks[taskID] = state
+	}
+	state.waiters = append(state.waiters, done)
+	w.mu.Unlock()
+	return done
+}
+
+// AwaitContext watches a task and waits for it to finish, like WaitContext, or until ctx is done.
+// When ctx is done the task is no longer watched and ctx.Err() is returned; the task itself keeps running.
+func (w *Watcher) AwaitContext(ctx context.Context, taskID string) (retTask models.Task, err error) {
+	select {
+	case event := <-w.Await(taskID):
+		return event.Task, event.Err
+	case <-ctx.Done():
+		w.Unwatch(taskID)
+		return retTask, ctx.Err()
+	}
+}
+
+// Unwatch stops watching a task without publishing a final event.
+func (w *Watcher) Unwatch(taskID string) {
+	w.mu.Lock()
+	delete(w.tasks, taskID)
+	w.mu.Unlock()
+}
+
+// Len returns the number of tasks in flight.
+func (w *Watcher) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.tasks)
+}
+
+// Events returns the channel of task events, used when no OnEvent callback is set.
+// Once Events has been called the channel must be drained, or polling blocks when its buffer is full.
+func (w *Watcher) Events() <-chan Event {
+	w.mu.Lock()
+	w.subscribed = true
+	w.mu.Unlock()
+	return w.events
+}
+
+// Stop ends the poll loop. Tasks still in flight get no final event.
+func (w *Watcher) Stop() {
+	w.once.Do(func() { close(w.stop) })
+}
+
+// interval is the poll interval for n tasks in flight.
+func (w *Watcher) interval(n int) time.Duration {
+	interval := w.opts.MinInterval * time.Duration(1+n/w.opts.BatchSize)
+	if interval > w.opts.MaxInterval {
+		interval = w.opts.MaxInterval
+	}
+	return interval
+}
+
+// loop polls the tasks in flight until the watcher is stopped.
+func (w *Watcher) loop() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-timer.C:
+		}
+
+		w.pollAll()
+		timer.Reset(w.interval(w.Len()))
+	}
+}
+
+// pollAll polls every task in flight, BatchSize at a time, and publishes the changes.
+func (w *Watcher) pollAll() {
+	w.mu.Lock()
+	ids := make([]string, 0, len(w.tasks))
+	for id := range w.tasks {
+		ids = append(ids, id)
+	}
+	w.mu.Unlock()
+
+	for start := 0; start < len(ids); start += w.opts.BatchSize {
+		end := start + w.opts.BatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		polled := make([]models.Task, end-start)
+		errs := make([]error, end-start)
+		var wg sync.WaitGroup
+		for i, id := range ids[start:end] {
+			wg.Add(1)
+			go func(i int, id string) {
+				defer wg.Done()
+				polled[i], errs[i] = GetTask(id, w.authToken, w.baseURL, w.httpClient)
+			}(i, id)
+		}
+		wg.Wait()
+
+		for i, id := range ids[start:end] {
+			if event, ok := w.update(id, polled[i], errs[i]); ok {
+				if !w.publish(event) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// update records a poll result and returns the event to publish, if any.
+func (w *Watcher) update(id string, polled models.Task, pollErr error) (event Event, changed bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state, ok := w.tasks[id]
+	if !ok {
+		return event, false
+	}
+	event = Event{TaskID: id, Task: state.task, Previous: state.status}
+
+	if pollErr != nil {
+		state.pollErrors++
+		if state.pollErrors < maxPollErrors {
+			return event, false
+		}
+		event.Err, event.Done = pollErr, true
+		w.finish(id, state, event)
+		return event, true
+	}
+	state.pollErrors = 0
+
+	status, err := CheckTransition(state.status, polled)
+	if err != nil {
+		state.badTransitions++
+		log.Printf("gojaqpot: task %s: %s", id, err.Error())
+		if state.badTransitions >= maxBadTransitions {
+			event.Task = polled
+			event.Err, event.Done = fmt.Errorf("task %s: %w", id, ErrTaskStalled), true
+			w.finish(id, state, event)
+			return event, true
+		}
+	}
+
+	changed = polled.HasStatus != state.task.HasStatus || polled.PercentageCompleted != state.task.PercentageCompleted
+	state.task = polled
+	if err == nil {
+		state.status = status
+	}
+	event.Task = polled
+
+	if status.IsTerminal() || (polled.PercentageCompleted == 100 && polled.Result != "" && status != models.TaskError) {
+		event.Err, event.Done = Outcome(polled), true
+		w.finish(id, state, event)
+		return event, true
+	}
+	return event, changed
+}
+
+// finish stops watching a task and hands its final event to its waiters. w.mu must be held.
+func (w *Watcher) finish(id string, state *watched, event Event) {
+	delete(w.tasks, id)
+	for _, waiter := range state.waiters {
+		waiter <- event
+	}
+}
+
+// publish hands an event to OnEvent or the Events channel; it reports false if the watcher was stopped.
+func (w *Watcher) publish(event Event) bool {
+	if w.opts.OnEvent != nil {
+		w.opts.OnEvent(event)
+		return true
+	}
+
+	w.mu.Lock()
+	subscribed := w.subscribed
+	w.mu.Unlock()
+	if !subscribed {
+		return true
+	}
+
+	select {
+	case w.events <- event:
+		return true
+	case <-w.stop:
+		return false
+	}
+}
@@ -0,0 +1,253 @@
+package gojaqpot
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+// tagName is the struct tag holding the Jaqpot feature name of a field.
+const tagName = "jaqpot"
+
+// TagError reports a struct field whose jaqpot tag does not match the input or the predictions.
+type TagError struct {
+	Type    reflect.Type
+	Field   string
+	Feature string
+	Message string
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("gojaqpot: %v.%s (feature %q): %s", e.Type, e.Field, e.Feature, e.Message)
+}
+
+// taggedField is a struct field carrying a jaqpot tag.
+type taggedField struct {
+	index   int
+	name    string
+	feature string
+}
+
+// PredictStructs is a method to make a prediction from a slice of structs whose fields are tagged with
+// Jaqpot feature names, e.g. `jaqpot:"LogP"`. Fields without a tag, or tagged "-", are ignored.
+// If out is not nil it must point to a slice of structs; each row of the prediction (input data and
+// predicted values) is decoded into one element, again matching fields by their jaqpot tag.
+func (client *Client) PredictStructs(modelID string, rows interface{}, out interface{}, AuthToken string) (prediction models.Prediction, err error) {
+	values, err := encodeRows(rows)
+	if err != nil {
+		return prediction, err
+	}
+
+	var outSlice reflect.Value
+	if out != nil {
+		ptr := reflect.ValueOf(out)
+		if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Slice || structType(ptr.Elem().Type().Elem()) == nil {
+			return prediction, fmt.Errorf("gojaqpot: out must be a non-nil pointer to a slice of structs, got %T", out)
+		}
+		if _, err = taggedFields(structType(ptr.Elem().Type().Elem())); err != nil {
+			return prediction, err
+		}
+		outSlice = ptr.Elem()
+	}
+
+	prediction, err = client.Predict(modelID, values, AuthToken)
+	if err != nil || out == nil {
+		return prediction, err
+	}
+
+	return prediction, decodePredictions(prediction, outSlice)
+}
+
+// encodeRows converts a slice of tagged structs (or pointers to them) into prediction input values.
+func encodeRows(rows interface{}) ([]map[string]interface{}, error) {
+	slice := reflect.ValueOf(rows)
+	if slice.Kind() != reflect.Slice && slice.Kind() != reflect.Array {
+		return nil, fmt.Errorf("gojaqpot: rows must be a slice of structs, got %T", rows)
+	}
+
+	elemType := structType(slice.Type().Elem())
+	if elemType == nil {
+		return nil, fmt.Errorf("gojaqpot: rows must be a slice of structs, got %T", rows)
+	}
+
+	fields, err := taggedFields(elemType)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]map[string]interface{}, slice.Len())
+	for i := 0; i < slice.Len(); i++ {
+		item := slice.Index(i)
+		if item.Kind() == reflect.Ptr {
+			if item.IsNil() {
+				return nil, fmt.Errorf("gojaqpot: rows[%d] is nil", i)
+			}
+			item = item.Elem()
+		}
+
+		values[i] = make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			values[i][field.feature] = item.Field(field.index).Interface()
+		}
+	}
+	return values, nil
+}
+
+// decodePredictions fills outSlice with one struct per predicted row.
+func decodePredictions(prediction models.Prediction, outSlice reflect.Value) error {
+	elemType := outSlice.Type().Elem()
+	fields, _ := taggedFields(structType(elemType))
+	result := reflect.MakeSlice(outSlice.Type(), len(prediction.Data), len(prediction.Data))
+
+	for i, data := range prediction.Data {
+		row := make(map[string]interface{}, len(data))
+		for key, val := range data {
+			row[key] = val
+		}
+		if i < len(prediction.Predictions) {
+			for key, val := range prediction.Predictions[i] {
+				row[key] = val
+			}
+		}
+
+		item := result.Index(i)
+		if elemType.Kind() == reflect.Ptr {
+			item.Set(reflect.New(elemType.Elem()))
+			item = item.Elem()
+		}
+
+		for _, field := range fields {
+			val, ok := row[field.feature]
+			if !ok {
+				return &TagError{Type: item.Type(), Field: field.name, Feature: field.feature, Message: fmt.Sprintf("not present in prediction row %d", i)}
+			}
+			if err := assignValue(item.Field(field.index), val); err != nil {
+				return &TagError{Type: item.Type(), Field: field.name, Feature: field.feature, Message: fmt.Sprintf("row %d: %s", i, err.Error())}
+			}
+		}
+	}
+
+	outSlice.Set(result)
+	return nil
+}
+
+// structType returns the struct type of t or of the type t points to, or nil.
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// taggedFields lists the fields of t carrying a jaqpot tag.
+func taggedFields(t reflect.Type) ([]taggedField, error) {
+	var fields []taggedField
+	seen := make(map[string]string)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup(tagName)
+		if !ok || tag == "-" {
+			continue
+		}
+
+		feature := strings.TrimSpace(tag)
+		if feature == "" {
+			return nil, &TagError{Type: t, Field: field.Name, Message: "empty jaqpot tag"}
+		}
+		if field.PkgPath != "" {
+			return nil, &TagError{Type: t, Field: field.Name, Feature: feature, Message: "field is unexported"}
+		}
+		if other, dup := seen[feature]; dup {
+			return nil, &TagError{Type: t, Field: field.Name, Feature: feature, Message: "feature already used by field " + other}
+		}
+
+		seen[feature] = field.Name
+		fields = append(fields, taggedField{index: i, name: field.Name, feature: feature})
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("gojaqpot: %v has no fields with a jaqpot tag", t)
+	}
+	return fields, nil
+}
+
+// assignValue stores a decoded JSON value into a struct field, converting between compatible kinds.
+func assignValue(field reflect.Value, val interface{}) error {
+	if val == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	if reflect.TypeOf(val).AssignableTo(field.Type()) {
+		field.Set(reflect.ValueOf(val))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(fmt.Sprintf("%v", val))
+		return nil
+	case reflect.Bool:
+		switch v := val.(type) {
+		case bool:
+			field.SetBool(v)
+			return nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return err
+			}
+			field.SetBool(b)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat(val)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, err := toFloat(val)
+		if err != nil {
+			return err
+		}
+		if f != float64(int64(f)) || field.OverflowInt(int64(f)) {
+			return fmt.Errorf("value %v does not fit in %v", val, field.Type())
+		}
+		field.SetInt(int64(f))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, err := toFloat(val)
+		if err != nil {
+			return err
+		}
+		if f < 0 || f != float64(uint64(f)) || field.OverflowUint(uint64(f)) {
+			return fmt.Errorf("value %v does not fit in %v", val, field.Type())
+		}
+		field.SetUint(uint64(f))
+		return nil
+	}
+
+	v := reflect.ValueOf(val)
+	if v.Type().ConvertibleTo(field.Type()) {
+		field.Set(v.Convert(field.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot store %T in a field of type %v", val, field.Type())
+}
+
+// toFloat converts a numeric value (see models.ToFloat) or numeric string to float64.
+func toFloat(val interface{}) (float64, error) {
+	if str, ok := val.(string); ok {
+		return strconv.ParseFloat(str, 64)
+	}
+	return models.ToFloat(val)
+}
@@ -0,0 +1,76 @@
+package units
+
+import (
+	"fmt"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+// Normalizer converts prediction inputs into the units a model expects.
+type Normalizer struct {
+	// Registry resolves unit symbols (defaults to Default).
+	Registry *Registry
+
+	// MolarMass holds the molar mass (g/mol) to use per feature name when converting
+	// between mass and amount of substance, e.g. mg/L to µM.
+	MolarMass map[string]float64
+}
+
+// NormalizeRows converts input values into the units of the model's input features, using a zero Normalizer.
+func NormalizeRows(inputs []models.FeatureSchema, rows []map[string]interface{}, valueUnits map[string]string) ([]map[string]interface{}, error) {
+	var n Normalizer
+	return n.NormalizeRows(inputs, rows, valueUnits)
+}
+
+// NormalizeRows converts input values into the units of the model's input features.
+// valueUnits maps feature names to the units the values are given in; other features are copied unchanged.
+// The rows passed in are not modified.
+func (n *Normalizer) NormalizeRows(inputs []models.FeatureSchema, rows []map[string]interface{}, valueUnits map[string]string) ([]map[string]interface{}, error) {
+	registry := n.Registry
+	if registry == nil {
+		registry = Default
+	}
+
+	expected := make(map[string]string, len(inputs))
+	for _, input := range inputs {
+		expected[input.Name] = input.Units
+	}
+
+	for name, from := range valueUnits {
+		to, ok := expected[name]
+		if !ok {
+			return nil, fmt.Errorf("units: %q is not an input feature of the model", name)
+		}
+		if to == "" {
+			return nil, fmt.Errorf("units: the model declares no units for feature %q, cannot convert from %q", name, from)
+		}
+	}
+
+	normalized := make([]map[string]interface{}, len(rows))
+	for row, item := range rows {
+		normalized[row] = make(map[string]interface{}, len(item))
+		for name, value := range item {
+			from, ok := valueUnits[name]
+			if !ok || from == expected[name] {
+				normalized[row][name] = value
+				continue
+			}
+
+			f, err := models.ToFloat(value)
+			if err != nil {
+				return nil, fmt.Errorf("units: row %d: feature %q: %s", row, name, err.Error())
+			}
+
+			if molarMass, ok := n.MolarMass[name]; ok {
+				f, err = registry.ConvertWithMolarMass(f, from, expected[name], molarMass)
+			} else {
+				f, err = registry.Convert(f, from, expected[name])
+			}
+			if err != nil {
+				return nil, fmt.Errorf("row %d: feature %q: %s", row, name, err.Error())
+			}
+			normalized[row][name] = f
+		}
+	}
+	return normalized, nil
+}
@@ -0,0 +1,243 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Base dimensions of a Unit.
+const (
+	Mass = iota
+	Length
+	Time
+	Amount
+	Temperature
+	Current
+	Luminosity
+	numDimensions
+)
+
+// Dimension holds the exponent of every base dimension.
+type Dimension [numDimensions]int
+
+// Unit is a parsed unit: a value v in this unit is v*Factor + Offset in SI base units.
+type Unit struct {
+	Symbol    string
+	Factor    float64
+	Offset    float64
+	Dimension Dimension
+	// Prefixable tells whether SI prefixes (m, µ, k, ...) may be applied to the symbol.
+	Prefixable bool
+}
+
+// IncompatibleError reports a conversion between units of different dimensions.
+type IncompatibleError struct {
+	From string
+	To   string
+}
+
+func (e *IncompatibleError) Error() string {
+	return fmt.Sprintf("units: cannot convert %q to %q: incompatible dimensions", e.From, e.To)
+}
+
+// Registry holds the known unit symbols.
+type Registry struct {
+	mu    sync.RWMutex
+	units map[string]Unit
+}
+
+// prefixes are the SI prefixes understood in front of prefixable symbols.
+var prefixes = map[string]float64{
+	"p": 1e-12, "n": 1e-9, "µ": 1e-6, "μ": 1e-6, "u": 1e-6, "m": 1e-3,
+	"c": 1e-2, "d": 1e-1, "da": 1e1, "h": 1e2, "k": 1e3, "M": 1e6, "G": 1e9,
+}
+
+// factorPattern matches a single factor of a unit expression, e.g. "cm3", "s^-1" or "L".
+var factorPattern = regexp.MustCompile(`^([^\d^\-+]+)\^?([-+]?\d+)?$`)
+
+// Default is the registry used by the package-level functions.
+var Default = NewRegistry()
+
+// NewRegistry creates a registry of common SI, concentration and temperature units.
+func NewRegistry() *Registry {
+	r := &Registry{units: make(map[string]Unit)}
+
+	r.Register(Unit{Symbol: "g", Factor: 1e-3, Dimension: Dimension{Mass: 1}, Prefixable: true})
+	r.Register(Unit{Symbol: "m", Factor: 1, Dimension: Dimension{Length: 1}, Prefixable: true})
+	r.Register(Unit{Symbol: "s", Factor: 1, Dimension: Dimension{Time: 1}, Prefixable: true})
+	r.Register(Unit{Symbol: "min", Factor: 60, Dimension: Dimension{Time: 1}})
+	r.Register(Unit{Symbol: "h", Factor: 3600, Dimension: Dimension{Time: 1}})
+	r.Register(Unit{Symbol: "d", Factor: 86400, Dimension: Dimension{Time: 1}})
+	r.Register(Unit{Symbol: "Hz", Factor: 1, Dimension: Dimension{Time: -1}, Prefixable: true})
+	r.Register(Unit{Symbol: "mol", Factor: 1, Dimension: Dimension{Amount: 1}, Prefixable: true})
+	r.Register(Unit{Symbol: "L", Factor: 1e-3, Dimension: Dimension{Length: 3}, Prefixable: true})
+	r.Register(Unit{Symbol: "l", Factor: 1e-3, Dimension: Dimension{Length: 3}, Prefixable: true})
+	r.Register(Unit{Symbol: "M", Factor: 1e3, Dimension: Dimension{Amount: 1, Length: -3}, Prefixable: true})
+	r.Register(Unit{Symbol: "Da", Factor: 1e-3, Dimension: Dimension{Mass: 1, Amount: -1}, Prefixable: true})
+	r.Register(Unit{Symbol: "A", Factor: 1, Dimension: Dimension{Current: 1}, Prefixable: true})
+	r.Register(Unit{Symbol: "cd", Factor: 1, Dimension: Dimension{Luminosity: 1}})
+	r.Register(Unit{Symbol: "K", Factor: 1, Dimension: Dimension{Temperature: 1}, Prefixable: true})
+	r.Register(Unit{Symbol: "°C", Factor: 1, Offset: 273.15, Dimension: Dimension{Temperature: 1}})
+	r.Register(Unit{Symbol: "degC", Factor: 1, Offset: 273.15, Dimension: Dimension{Temperature: 1}})
+	r.Register(Unit{Symbol: "°F", Factor: 5.0 / 9, Offset: 273.15 - 32*5.0/9, Dimension: Dimension{Temperature: 1}})
+	r.Register(Unit{Symbol: "degF", Factor: 5.0 / 9, Offset: 273.15 - 32*5.0/9, Dimension: Dimension{Temperature: 1}})
+	r.Register(Unit{Symbol: "%", Factor: 1e-2})
+	r.Register(Unit{Symbol: "‰", Factor: 1e-3})
+	r.Register(Unit{Symbol: "ppm", Factor: 1e-6})
+	r.Register(Unit{Symbol: "ppb", Factor: 1e-9})
+	return r
+}
+
+// Register adds or replaces a unit symbol.
+func (r *Registry) Register(u Unit) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.units[u.Symbol] = u
+}
+
+// Parse parses a unit expression such as "mg/L", "µM", "mol/m^3" or "g·cm-3".
+// Factors are separated by "*", "·" or spaces; every factor after a "/" is a divisor.
+func (r *Registry) Parse(expr string) (u Unit, err error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return u, fmt.Errorf("units: empty unit")
+	}
+
+	u = Unit{Symbol: expr, Factor: 1}
+	for i, part := range strings.Split(expr, "/") {
+		sign := 1
+		if i > 0 {
+			sign = -1
+		}
+
+		factors := strings.FieldsFunc(part, func(c rune) bool { return c == '*' || c == '·' || c == ' ' })
+		if len(factors) == 0 {
+			return u, fmt.Errorf("units: malformed unit %q", expr)
+		}
+
+		for _, factor := range factors {
+			base, exp, err := r.parseFactor(factor)
+			if err != nil {
+				return u, fmt.Errorf("units: %q: %s", expr, err.Error())
+			}
+			exp *= sign
+
+			if base.Offset != 0 {
+				if len(factors) != 1 || i > 0 || exp != 1 || strings.Contains(expr, "/") {
+					return u, fmt.Errorf("units: %q: %s can only be used on its own", expr, base.Symbol)
+				}
+				u.Offset = base.Offset
+			}
+
+			u.Factor *= math.Pow(base.Factor, float64(exp))
+			for d := range u.Dimension {
+				u.Dimension[d] += base.Dimension[d] * exp
+			}
+		}
+	}
+	return u, nil
+}
+
+// parseFactor parses a single symbol with an optional exponent, trying SI prefixes if needed.
+func (r *Registry) parseFactor(factor string) (u Unit, exp int, err error) {
+	match := factorPattern.FindStringSubmatch(factor)
+	if match == nil {
+		if factor == "1" {
+			return Unit{Symbol: "1", Factor: 1}, 1, nil
+		}
+		return u, 0, fmt.Errorf("malformed factor %q", factor)
+	}
+
+	exp = 1
+	if match[2] != "" {
+		exp, _ = strconv.Atoi(match[2])
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	symbol := match[1]
+	if u, ok := r.units[symbol]; ok {
+		return u, exp, nil
+	}
+	for prefix, scale := range prefixes {
+		if !strings.HasPrefix(symbol, prefix) {
+			continue
+		}
+		if u, ok := r.units[strings.TrimPrefix(symbol, prefix)]; ok && u.Prefixable {
+			u.Symbol = symbol
+			u.Factor *= scale
+			return u, exp, nil
+		}
+	}
+	return u, 0, fmt.Errorf("unknown unit %q", symbol)
+}
+
+// Convert converts a value between two unit expressions of the same dimension.
+func (r *Registry) Convert(value float64, from string, to string) (float64, error) {
+	fromUnit, err := r.Parse(from)
+	if err != nil {
+		return 0, err
+	}
+	toUnit, err := r.Parse(to)
+	if err != nil {
+		return 0, err
+	}
+	if fromUnit.Dimension != toUnit.Dimension {
+		return 0, &IncompatibleError{From: from, To: to}
+	}
+	return (value*fromUnit.Factor + fromUnit.Offset - toUnit.Offset) / toUnit.Factor, nil
+}
+
+// ConvertWithMolarMass is like Convert, but also converts between mass and amount of substance
+// (e.g. mg/L and µM) using the molar mass of the substance, in g/mol.
+func (r *Registry) ConvertWithMolarMass(value float64, from string, to string, molarMass float64) (float64, error) {
+	fromUnit, err := r.Parse(from)
+	if err != nil {
+		return 0, err
+	}
+	toUnit, err := r.Parse(to)
+	if err != nil {
+		return 0, err
+	}
+	if fromUnit.Dimension == toUnit.Dimension {
+		return r.Convert(value, from, to)
+	}
+
+	// The dimensions may only differ by k kilograms traded for k moles.
+	k := fromUnit.Dimension[Mass] - toUnit.Dimension[Mass]
+	diff := fromUnit.Dimension
+	for d := range diff {
+		diff[d] -= toUnit.Dimension[d]
+	}
+	if k == 0 || molarMass <= 0 || diff != (Dimension{Mass: k, Amount: -k}) || fromUnit.Offset != 0 || toUnit.Offset != 0 {
+		return 0, &IncompatibleError{From: from, To: to}
+	}
+
+	si := value * fromUnit.Factor / math.Pow(molarMass*1e-3, float64(k))
+	return si / toUnit.Factor, nil
+}
+
+// Parse parses a unit expression with the Default registry.
+func Parse(expr string) (Unit, error) {
+	return Default.Parse(expr)
+}
+
+// Convert converts a value between two unit expressions with the Default registry.
+func Convert(value float64, from string, to string) (float64, error) {
+	return Default.Convert(value, from, to)
+}
+
+// Compatible tells whether values can be converted between two unit expressions with the Default registry.
+func Compatible(from string, to string) bool {
+	fromUnit, err := Default.Parse(from)
+	if err != nil {
+		return false
+	}
+	toUnit, err := Default.Parse(to)
+	return err == nil && fromUnit.Dimension == toUnit.Dimension
+}
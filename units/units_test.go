@@ -0,0 +1,197 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/euclia/gojaqpot/models"
+)
+
+// near tells whether two values are equal up to a relative tolerance.
+func near(got float64, want float64) bool {
+	return math.Abs(got-want) <= 1e-9*math.Max(1, math.Abs(want))
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from, to string
+		want     float64
+	}{
+		{1, "g", "mg", 1000},
+		{1, "mg/L", "µg/L", 1000},
+		{1, "mg/L", "μg/mL", 1},
+		{1, "mM", "µM", 1000},
+		{2.5, "uM", "nM", 2500},
+		{1, "mol/m^3", "mM", 1},
+		{1, "g·cm-3", "kg/m3", 1000},
+		{1, "g*cm^-3", "g/mL", 1},
+		{1, "L", "cm3", 1000},
+		{1, "h", "min", 60},
+		{2, "d", "h", 48},
+		{1, "kHz", "Hz", 1000},
+		{1, "1/s", "Hz", 1},
+		{25, "°C", "K", 298.15},
+		{212, "degF", "°C", 100},
+		{-40, "°C", "°F", -40},
+		{50, "%", "1", 0.5},
+		{1, "‰", "ppm", 1000},
+		{180.16, "g/mol", "Da", 180.16},
+	}
+
+	for _, test := range tests {
+		got, err := Convert(test.value, test.from, test.to)
+		if err != nil {
+			t.Errorf("Convert(%v, %q, %q): %v", test.value, test.from, test.to, err)
+			continue
+		}
+		if !near(got, test.want) {
+			t.Errorf("Convert(%v, %q, %q) = %v, want %v", test.value, test.from, test.to, got, test.want)
+		}
+	}
+}
+
+func TestConvertIncompatible(t *testing.T) {
+	for _, pair := range [][2]string{{"mg/L", "µM"}, {"g", "m"}, {"s", "Hz"}, {"K", "%"}} {
+		_, err := Convert(1, pair[0], pair[1])
+		var incompatible *IncompatibleError
+		if !errors.As(err, &incompatible) {
+			t.Errorf("Convert(1, %q, %q) error = %v, want an IncompatibleError", pair[0], pair[1], err)
+			continue
+		}
+		if incompatible.From != pair[0] || incompatible.To != pair[1] {
+			t.Errorf("IncompatibleError = %+v", incompatible)
+		}
+	}
+	if Compatible("mg/L", "µM") || !Compatible("mg/L", "g/m3") || Compatible("mg/L", "furlong") {
+		t.Error("Compatible disagrees with Convert")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "  ", "furlong", "mg//L", "°C/s", "°C^2", "kmin", "m^x"} {
+		if u, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) = %+v, want an error", expr, u)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	u, err := Parse("mg/L")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Symbol != "mg/L" || !near(u.Factor, 1e-3) || u.Dimension != (Dimension{Mass: 1, Length: -3}) {
+		t.Errorf("Parse(mg/L) = %+v", u)
+	}
+}
+
+func TestConvertWithMolarMass(t *testing.T) {
+	const glucose = 180.16
+
+	tests := []struct {
+		value    float64
+		from, to string
+		want     float64
+	}{
+		{180.16, "mg/L", "µM", 1000},
+		{1000, "µM", "mg/L", 180.16},
+		{1, "mol", "g", 180.16},
+		{360.32, "g", "mol", 2},
+		{1, "mg/L", "g/L", 1e-3},
+	}
+	for _, test := range tests {
+		got, err := Default.ConvertWithMolarMass(test.value, test.from, test.to, glucose)
+		if err != nil {
+			t.Errorf("ConvertWithMolarMass(%v, %q, %q): %v", test.value, test.from, test.to, err)
+			continue
+		}
+		if !near(got, test.want) {
+			t.Errorf("ConvertWithMolarMass(%v, %q, %q) = %v, want %v", test.value, test.from, test.to, got, test.want)
+		}
+	}
+
+	for _, pair := range [][2]string{{"mg/L", "m"}, {"°C", "mol"}} {
+		if _, err := Default.ConvertWithMolarMass(1, pair[0], pair[1], glucose); err == nil {
+			t.Errorf("ConvertWithMolarMass(1, %q, %q) succeeded", pair[0], pair[1])
+		}
+	}
+	if _, err := Default.ConvertWithMolarMass(1, "mg/L", "µM", 0); err == nil {
+		t.Error("ConvertWithMolarMass succeeded without a molar mass")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	r := NewRegistry()
+	r.Register(Unit{Symbol: "ft", Factor: 0.3048, Dimension: Dimension{Length: 1}})
+
+	got, err := r.Convert(1, "ft", "cm")
+	if err != nil || !near(got, 30.48) {
+		t.Errorf("Convert(1, ft, cm) = %v, %v; want 30.48", got, err)
+	}
+	if _, err = r.Parse("kft"); err == nil {
+		t.Error("a prefix was applied to a unit that is not prefixable")
+	}
+	if _, err = Default.Parse("ft"); err == nil {
+		t.Error("registering in one registry changed the Default registry")
+	}
+}
+
+func TestNormalizeRows(t *testing.T) {
+	inputs := []models.FeatureSchema{
+		{Name: "Concentration", Units: "µM"},
+		{Name: "Temperature", Units: "K"},
+		{Name: "Dose", Units: "mg"},
+	}
+	rows := []map[string]interface{}{{"Concentration": 180.16, "Temperature": 25, "Dose": 2}}
+	valueUnits := map[string]string{"Concentration": "mg/L", "Temperature": "°C", "Dose": "mg"}
+
+	// Mass concentrations cannot become molar ones without the molar mass.
+	if _, err := NormalizeRows(inputs, rows, valueUnits); err == nil || !strings.Contains(err.Error(), "incompatible") {
+		t.Errorf("error = %v, want an incompatible units error", err)
+	}
+
+	n := Normalizer{MolarMass: map[string]float64{"Concentration": 180.16}}
+	normalized, err := n.NormalizeRows(inputs, rows, valueUnits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := normalized[0]["Concentration"].(float64); !near(got, 1000) {
+		t.Errorf("Concentration = %v µM, want 1000", got)
+	}
+	if got := normalized[0]["Temperature"].(float64); !near(got, 298.15) {
+		t.Errorf("Temperature = %v K, want 298.15", got)
+	}
+	if normalized[0]["Dose"] != 2 {
+		t.Errorf("Dose = %#v, want the value unchanged", normalized[0]["Dose"])
+	}
+	if rows[0]["Concentration"] != 180.16 {
+		t.Error("the rows passed in were modified")
+	}
+}
+
+func TestNormalizeRowsErrors(t *testing.T) {
+	inputs := []models.FeatureSchema{{Name: "MW", Units: "g/mol"}, {Name: "Count"}}
+	var n Normalizer
+
+	tests := []struct {
+		name       string
+		rows       []map[string]interface{}
+		valueUnits map[string]string
+		want       string
+	}{
+		{"unknown feature", nil, map[string]string{"LogP": "1"}, "not an input feature"},
+		{"model without units", nil, map[string]string{"Count": "%"}, "declares no units"},
+		{"not numeric", []map[string]interface{}{{"MW": "heavy"}}, map[string]string{"MW": "kg/mol"}, "row 0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := n.NormalizeRows(inputs, test.rows, test.valueUnits)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error = %v, want one containing %q", err, test.want)
+			}
+		})
+	}
+}